@@ -0,0 +1,191 @@
+package beater
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+
+	"github.com/counteractive/o365beat/config"
+	"github.com/counteractive/o365beat/o365api"
+)
+
+// apiRetention is how long the API keeps content available
+const apiRetention = 7 * 24 * time.Hour
+
+// BackfillOptions selects the content collected by a backfill
+type BackfillOptions struct {
+	Start        time.Time
+	End          time.Time
+	ContentTypes []string // defaults to each tenant's configured content types
+	Tenants      []string // tenant names, defaults to all configured tenants
+}
+
+// Backfill collects content created in a past time window and exits once all
+// of it has been acknowledged by the output.  it keeps its own in-memory
+// registry, so the live registry file is neither read nor updated.
+type Backfill struct {
+	done          chan struct{}
+	config        config.Config
+	tenantConfigs []config.TenantConfig
+	options       BackfillOptions
+	tenants       []*tenant
+}
+
+// NewBackfill returns a beat.Creator for a backfill with the given options
+func NewBackfill(options BackfillOptions) beat.Creator {
+	return func(b *beat.Beat, cfg *common.Config) (beat.Beater, error) {
+		base, err := newO365beat(cfg)
+		if err != nil {
+			return nil, err
+		}
+		tenants, err := selectTenants(base.tenantConfigs, options.Tenants)
+		if err != nil {
+			logp.Error(err)
+			return nil, err
+		}
+
+		// the window is explicit, so only the API's retention limits it
+		now := time.Now()
+		if options.End.IsZero() || options.End.After(now) {
+			options.End = now
+		}
+		if oldest := now.Add(-apiRetention); options.Start.Before(oldest) {
+			logp.Warn("backfill start (%v) is beyond the API's %v retention, starting at %v", options.Start, apiRetention, oldest)
+			options.Start = oldest
+		}
+		if !options.Start.Before(options.End) {
+			err := fmt.Errorf("backfill start (%v) must be before end (%v)", options.Start, options.End)
+			logp.Error(err)
+			return nil, err
+		}
+		c := base.config
+		c.ContentMaxAge = apiRetention
+
+		bt := &Backfill{
+			done:          make(chan struct{}),
+			config:        c,
+			tenantConfigs: tenants,
+			options:       options,
+		}
+		return bt, nil
+	}
+}
+
+// selectTenants returns the named tenants (all of them if names is empty)
+func selectTenants(tenants []config.TenantConfig, names []string) ([]config.TenantConfig, error) {
+	if len(names) == 0 {
+		return tenants, nil
+	}
+	var selected []config.TenantConfig
+	for _, name := range names {
+		found := false
+		for _, t := range tenants {
+			if t.Name == name {
+				selected = append(selected, t)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no configured tenant named %v", name)
+		}
+	}
+	return selected, nil
+}
+
+// Run collects the window for each tenant in turn, then waits for the output
+// to acknowledge everything published.
+func (bt *Backfill) Run(b *beat.Beat) error {
+	logp.Info("backfilling content created between %v and %v", bt.options.Start, bt.options.End)
+
+	// a throwaway registry: it only tracks acknowledgements (and skips blobs
+	// listed twice), nothing is persisted
+	registrar := newRegistrar(newRegistry(), bt.config.ContentOverlap, func([]byte) error { return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-bt.done
+		cancel()
+	}()
+	defer func() {
+		for _, t := range bt.tenants {
+			t.client.Close()
+		}
+	}()
+
+	for _, tc := range bt.tenantConfigs {
+		// backfills may run alongside the beat, so they log dead letters rather
+		// than sharing its dead-letter store
+		t, err := newTenant(bt.config, tc, registrar, nil)
+		if err != nil {
+			logp.Error(err)
+			return err
+		}
+		// backfills don't change subscriptions, they report missing ones instead
+		t.resubscribe = false
+		t.client, err = b.Publisher.ConnectWith(beat.ClientConfig{
+			PublishMode: beat.GuaranteedSend,
+			ACKEvents:   registrar.ackEvents,
+		})
+		if err != nil {
+			logp.Error(err)
+			return err
+		}
+		bt.tenants = append(bt.tenants, t)
+
+		if err := bt.backfill(ctx, t); err != nil {
+			return err
+		}
+	}
+
+	logp.Info("backfill published, waiting for the output to acknowledge all events")
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for !registrar.drained() {
+		select {
+		case <-ctx.Done():
+			logp.Warn("backfill stopped before all events were acknowledged")
+			return nil
+		case <-ticker.C:
+		}
+	}
+	logp.Info("backfill complete")
+	return nil
+}
+
+// backfill gets and publishes a tenant's content for the window
+func (bt *Backfill) backfill(ctx context.Context, t *tenant) error {
+	contentTypes := bt.options.ContentTypes
+	if len(contentTypes) == 0 {
+		contentTypes = t.config.ContentTypes
+	}
+	starts := map[string]time.Time{}
+	for _, contentType := range contentTypes {
+		starts[contentType] = bt.options.Start
+	}
+
+	listed, err := t.listAllAvailableContent(ctx, starts, bt.options.End)
+	if err != nil {
+		err = fmt.Errorf("error listing content to backfill for %v: %v", t.config.Name, err)
+		logp.Error(err)
+		return err
+	}
+	var availableContent []o365api.Content
+	for _, blob := range listed {
+		if !t.registrar.skip(t.config.RegistryNamespace, blob) {
+			availableContent = append(availableContent, blob)
+		}
+	}
+	logp.Info("backfilling %v blob(s) for %v", len(availableContent), t.config.Name)
+	return t.process(ctx, availableContent, false)
+}
+
+// Stop stops the backfill (Run closes the pipeline clients once it's stopped).
+func (bt *Backfill) Stop() {
+	close(bt.done)
+}
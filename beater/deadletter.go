@@ -0,0 +1,251 @@
+package beater
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+
+	"github.com/counteractive/o365beat/o365api"
+)
+
+// kinds of dead letters
+const (
+	DeadLetterBlob  = "blob"  // a blob that couldn't be downloaded or decoded, retried until it expires
+	DeadLetterEvent = "event" // an event that couldn't be published (e.g., an unparseable CreationTime)
+)
+
+// maxDeadLetterPayload is the most of an undecodable blob kept with its dead letter
+const maxDeadLetterPayload = 64 << 10
+
+// DeadLetter is something the beat couldn't publish, kept (see dead_letter_path)
+// so it isn't silently lost
+type DeadLetter struct {
+	Kind              string        `json:"kind"`
+	Tenant            string        `json:"tenant"` // registry namespace
+	ContentType       string        `json:"contentType"`
+	ContentID         string        `json:"contentId"`
+	ContentURI        string        `json:"contentUri"`
+	ContentCreated    time.Time     `json:"contentCreated"`
+	ContentExpiration time.Time     `json:"contentExpiration"`
+	Reason            string        `json:"reason"`
+	Attempts          int           `json:"attempts"`
+	FirstFailed       time.Time     `json:"firstFailed"`
+	LastFailed        time.Time     `json:"lastFailed"`
+	Event             common.MapStr `json:"event,omitempty"`
+	Payload           string        `json:"payload,omitempty"` // an undecodable blob, up to maxDeadLetterPayload
+	PayloadTruncated  bool          `json:"payloadTruncated,omitempty"`
+}
+
+// key identifies a dead letter, so failing again updates it rather than adding
+// another.  events are identified by their Id, or if they have none, by a hash of
+// their content, so a blob's events without ids don't replace each other.
+func (l *DeadLetter) key() string {
+	k := []string{l.Kind, l.Tenant, l.ContentID}
+	if l.Event != nil {
+		id, _ := l.Event["Id"].(string)
+		if id == "" {
+			data, _ := json.Marshal(l.Event)
+			sum := sha256.Sum256(data)
+			id = "sha256:" + hex.EncodeToString(sum[:])
+		}
+		k = append(k, id)
+	}
+	return strings.Join(k, "|")
+}
+
+// content returns the blob the dead letter came from
+func (l *DeadLetter) content() o365api.Content {
+	return o365api.Content{
+		ContentType:       l.ContentType,
+		ContentID:         l.ContentID,
+		ContentURI:        l.ContentURI,
+		ContentCreated:    l.ContentCreated,
+		ContentExpiration: l.ContentExpiration,
+	}
+}
+
+// expired reports whether the API no longer has the dead letter's blob
+func (l *DeadLetter) expired(now time.Time) bool {
+	if !l.ContentExpiration.IsZero() {
+		return now.After(l.ContentExpiration)
+	}
+	return now.After(l.ContentCreated.Add(apiRetention))
+}
+
+// newBlobDeadLetter describes a blob that couldn't be downloaded or decoded
+func newBlobDeadLetter(tenant string, blob o365api.Content, err error) DeadLetter {
+	l := DeadLetter{
+		Kind:              DeadLetterBlob,
+		Tenant:            tenant,
+		ContentType:       blob.ContentType,
+		ContentID:         blob.ContentID,
+		ContentURI:        blob.ContentURI,
+		ContentCreated:    blob.ContentCreated,
+		ContentExpiration: blob.ContentExpiration,
+		Reason:            err.Error(),
+	}
+	if e, ok := err.(*o365api.ContentDecodeError); ok {
+		payload := e.Payload
+		if len(payload) > maxDeadLetterPayload {
+			payload = payload[:maxDeadLetterPayload]
+			l.PayloadTruncated = true
+		}
+		l.Payload = string(payload)
+	}
+	return l
+}
+
+// newEventDeadLetter describes an event of blob that couldn't be published
+func newEventDeadLetter(tenant string, blob o365api.Content, evt common.MapStr, reason string) DeadLetter {
+	return DeadLetter{
+		Kind:              DeadLetterEvent,
+		Tenant:            tenant,
+		ContentType:       blob.ContentType,
+		ContentID:         blob.ContentID,
+		ContentURI:        blob.ContentURI,
+		ContentCreated:    blob.ContentCreated,
+		ContentExpiration: blob.ContentExpiration,
+		Reason:            reason,
+		Event:             evt,
+	}
+}
+
+// deadLetterStore keeps dead letters in a json file, replaced atomically on
+// each change.  it's safe for concurrent use by the tenants, but only one
+// process may use it (it's guarded by the registry lock).
+type deadLetterStore struct {
+	path    string
+	mutex   sync.Mutex
+	letters map[string]*DeadLetter
+}
+
+// openDeadLetterStore loads the store at path (which may not exist yet)
+func openDeadLetterStore(path string) (*deadLetterStore, error) {
+	s := &deadLetterStore{path: path, letters: map[string]*DeadLetter{}}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var letters []*DeadLetter
+	if err := json.Unmarshal(data, &letters); err != nil {
+		return nil, err
+	}
+	for _, l := range letters {
+		s.letters[l.key()] = l
+	}
+	return s, nil
+}
+
+// add records failures (e.g., a blob's events) with a single write, counting
+// the attempt of any that failed before.  if the write fails, the store is left
+// as it was.
+func (s *deadLetterStore) add(letters ...DeadLetter) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := time.Now()
+	previous := map[string]*DeadLetter{} // nil for added letters
+	for _, l := range letters {
+		key := l.key()
+		existing, ok := s.letters[key]
+		if _, seen := previous[key]; !seen {
+			previous[key] = existing
+		}
+		if ok {
+			updated := *existing
+			updated.Reason = l.Reason
+			updated.Attempts++
+			updated.LastFailed = now
+			if l.Event != nil {
+				updated.Event = l.Event
+			}
+			updated.Payload, updated.PayloadTruncated = l.Payload, l.PayloadTruncated
+			s.letters[key] = &updated
+		} else {
+			l.Attempts = 1
+			l.FirstFailed, l.LastFailed = now, now
+			s.letters[key] = &l
+		}
+	}
+	if err := s.save(); err != nil {
+		for key, l := range previous {
+			if l == nil {
+				delete(s.letters, key)
+			} else {
+				s.letters[key] = l
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// remove deletes dead letters (e.g., once replayed), if they're in the store
+func (s *deadLetterStore) remove(letters ...DeadLetter) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	removed := false
+	for _, l := range letters {
+		if _, ok := s.letters[l.key()]; ok {
+			delete(s.letters, l.key())
+			removed = true
+		}
+	}
+	if !removed {
+		return nil
+	}
+	return s.save()
+}
+
+// list returns the dead letters, oldest content first
+func (s *deadLetterStore) list() []DeadLetter {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	keys := make([]string, 0, len(s.letters))
+	for key := range s.letters {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := s.letters[keys[i]], s.letters[keys[j]]
+		if !a.ContentCreated.Equal(b.ContentCreated) {
+			return a.ContentCreated.Before(b.ContentCreated)
+		}
+		return keys[i] < keys[j]
+	})
+	letters := make([]DeadLetter, 0, len(keys))
+	for _, key := range keys {
+		letters = append(letters, *s.letters[key])
+	}
+	return letters
+}
+
+// save writes the store to a temporary file and renames it into place
+func (s *deadLetterStore) save() error {
+	letters := make([]*DeadLetter, 0, len(s.letters))
+	for _, l := range s.letters {
+		letters = append(letters, l)
+	}
+	data, err := json.MarshalIndent(letters, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileSync(s.path+".tmp", data); err != nil {
+		return err
+	}
+	if err := os.Rename(s.path+".tmp", s.path); err != nil {
+		return err
+	}
+	syncDir(filepath.Dir(s.path))
+	return nil
+}
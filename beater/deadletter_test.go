@@ -0,0 +1,274 @@
+// +build !integration
+
+package beater
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+
+	"github.com/counteractive/o365beat/config"
+	"github.com/counteractive/o365beat/o365api"
+)
+
+var errTest = errors.New("unavailable")
+
+// testDeadLetterStore opens a dead-letter store in dir
+func testDeadLetterStore(t *testing.T, dir string) *deadLetterStore {
+	s, err := openDeadLetterStore(filepath.Join(dir, "o365beat.deadletters"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// keys returns the keys of letters, in order
+func keys(letters []DeadLetter) string {
+	var k []string
+	for _, l := range letters {
+		k = append(k, l.key())
+	}
+	return strings.Join(k, ", ")
+}
+
+func TestDeadLetterStore(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	s := testDeadLetterStore(t, dir)
+
+	// events without ids are told apart by their content
+	blob := testBlob("a", 0)
+	letters := []DeadLetter{
+		newEventDeadLetter("t", blob, common.MapStr{"Operation": "A"}, "no CreationTime"),
+		newEventDeadLetter("t", blob, common.MapStr{"Operation": "B"}, "no CreationTime"),
+		newEventDeadLetter("t", blob, common.MapStr{"Id": "c", "Operation": "C"}, "no CreationTime"),
+		newBlobDeadLetter("t", testBlob("b", time.Second), &o365api.ContentDecodeError{Payload: []byte("<html>"), Err: errTest}),
+	}
+	if err := s.add(letters...); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.list(); len(got) != 4 {
+		t.Fatalf("got %v dead letters, want 4: %v", len(got), keys(got))
+	}
+	if err := s.add(letters[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := testDeadLetterStore(t, dir)
+	got := reopened.list()
+	if keys(got) != keys(s.list()) {
+		t.Fatalf("reopened store has %v, want %v", keys(got), keys(s.list()))
+	}
+	for _, l := range got {
+		attempts := 1
+		if l.key() == letters[1].key() {
+			attempts = 2
+		}
+		if l.Attempts != attempts || l.FirstFailed.IsZero() || l.LastFailed.Before(l.FirstFailed) {
+			t.Errorf("%v: got %v attempt(s) failing %v to %v, want %v", l.key(), l.Attempts, l.FirstFailed, l.LastFailed, attempts)
+		}
+	}
+	if l := got[len(got)-1]; l.Kind != DeadLetterBlob || l.Payload != "<html>" {
+		t.Errorf("got %+v, want the blob's payload", l)
+	}
+
+	if err := reopened.remove(got[0], got[1]); err != nil {
+		t.Fatal(err)
+	}
+	if got := testDeadLetterStore(t, dir).list(); keys(got) != keys(reopened.list()) || len(got) != 2 {
+		t.Errorf("after removing 2: got %v", keys(got))
+	}
+}
+
+func TestDeadLetterStoreWriteFailure(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	s := testDeadLetterStore(t, dir)
+	first := newBlobDeadLetter("t", testBlob("a", 0), errTest)
+	if err := s.add(first); err != nil {
+		t.Fatal(err)
+	}
+
+	s.path = filepath.Join(dir, "missing", "o365beat.deadletters")
+	if err := s.add(first, newBlobDeadLetter("t", testBlob("b", 0), errTest)); err == nil {
+		t.Fatal("no error writing to a missing directory")
+	}
+	if got := s.list(); len(got) != 1 || got[0].Attempts != 1 {
+		t.Errorf("failed add changed the store: %+v", got)
+	}
+}
+
+func TestDeadLetterExpired(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		created, expiration time.Time
+		want                bool
+	}{
+		{now.Add(-time.Hour), now.Add(time.Hour), false},
+		{now.Add(-time.Hour), now.Add(-time.Minute), true},
+		{now.Add(-time.Hour), time.Time{}, false},
+		{now.Add(-apiRetention - time.Minute), time.Time{}, true},
+	}
+	for _, test := range tests {
+		l := DeadLetter{ContentCreated: test.created, ContentExpiration: test.expiration}
+		if got := l.expired(now); got != test.want {
+			t.Errorf("created %v, expiring %v: got %v, want %v", test.created, test.expiration, got, test.want)
+		}
+	}
+}
+
+func TestProcessDeadLetters(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	s := newContentServer(nil, map[string]int{"b": http.StatusInternalServerError, "d": http.StatusInternalServerError})
+	defer s.Close()
+	tn, client := newTestTenant(t, s, 2)
+	tn.deadLetters = testDeadLetterStore(t, dir)
+
+	if err := tn.process(context.Background(), s.blobs("a", "b", "c"), false); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(client.published(), ""); got != "ac" {
+		t.Errorf("published %v, want ac", got)
+	}
+	if got := tn.deadLetters.list(); len(got) != 1 || got[0].ContentID != "b" || got[0].Kind != DeadLetterBlob {
+		t.Errorf("got dead letters %+v, want blob b", got)
+	}
+
+	// if the failure can't be kept, the blob and those after it are left for the
+	// next poll
+	tn.deadLetters.path = filepath.Join(dir, "missing", "o365beat.deadletters")
+	if err := tn.process(context.Background(), s.blobs("d", "e"), false); err == nil {
+		t.Fatal("no error when the dead-letter store can't be written")
+	}
+	for _, blob := range s.blobs("d", "e") {
+		if tn.registrar.skip("t", blob) {
+			t.Errorf("blob %v was queued", blob.ContentID)
+		}
+	}
+}
+
+func TestProcessDeadLettersEvents(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	s := newContentServer(nil, nil)
+	s.bodies = map[string]string{
+		"a": `[{"Operation":"A"},{"Operation":"B"},{"Id":"c","CreationTime":"2020-01-02T03:04:05"}]`,
+		"b": `[{"Operation":"A"}]`,
+	}
+	defer s.Close()
+	tn, client := newTestTenant(t, s, 1)
+	tn.global.TimestampFallback = config.TimestampFallbackNone
+	tn.deadLetters = testDeadLetterStore(t, dir)
+
+	if err := tn.process(context.Background(), s.blobs("a"), false); err != nil {
+		t.Fatal(err)
+	}
+	if got := client.privates("a"); len(got) != 1 {
+		t.Errorf("published %v of a's events, want 1", len(got))
+	}
+	if got := tn.deadLetters.list(); len(got) != 2 || got[0].Kind != DeadLetterEvent || got[1].Kind != DeadLetterEvent {
+		t.Errorf("got dead letters %+v, want both of a's events without a CreationTime", got)
+	}
+
+	tn.deadLetters.path = filepath.Join(dir, "missing", "o365beat.deadletters")
+	if err := tn.process(context.Background(), s.blobs("b"), false); err == nil {
+		t.Fatal("no error when the dead-letter store can't be written")
+	}
+	if tn.registrar.skip("t", s.blobs("b")[0]) || len(client.privates("b")) > 0 {
+		t.Error("blob b was queued or published")
+	}
+}
+
+func TestRetryDeadLetters(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	s := newContentServer(nil, nil)
+	defer s.Close()
+	tn, client := newTestTenant(t, s, 1)
+	tn.deadLetters = testDeadLetterStore(t, dir)
+
+	now := time.Now()
+	blobs := s.blobs("expired", "b", "other")
+	blobs[0].ContentExpiration = now.Add(-time.Minute)
+	blobs[1].ContentExpiration = now.Add(time.Hour)
+	blobs[2].ContentExpiration = now.Add(time.Hour)
+	blobs[2].ContentType = "Audit.Exchange" // not collected by the tenant
+	for _, blob := range blobs {
+		if err := tn.deadLetters.add(newBlobDeadLetter("t", blob, errTest)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	event := newEventDeadLetter("t", blobs[1], common.MapStr{"Operation": "A"}, "no CreationTime")
+	if err := tn.deadLetters.add(event); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tn.retryDeadLetters(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(client.published(), ""); got != "b" {
+		t.Errorf("retried %v, want b", got)
+	}
+	if got := keys(tn.deadLetters.list()); strings.Contains(got, "expired") {
+		t.Errorf("kept the expired blob: %v", got)
+	}
+	tn.registrar.ackEvents(client.privates("b"))
+	want := keys([]DeadLetter{event, newBlobDeadLetter("t", blobs[2], errTest)})
+	if got := keys(tn.deadLetters.list()); got != want {
+		t.Errorf("after acknowledging b: got %v, want %v", got, want)
+	}
+	// retried blobs are marked processed, without moving the cursor
+	if c := tn.registrar.reg.cursor("t", "Audit.General"); !c.processed(blobs[1]) || c.ContentID != "" {
+		t.Errorf("got cursor %+v", c)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	s := newContentServer(nil, nil)
+	defer s.Close()
+	tn, client := newTestTenant(t, s, 1)
+	tn.global.TimestampFallback = config.TimestampFallbackContentCreated
+	tn.deadLetters = testDeadLetterStore(t, dir)
+
+	blobs := s.blobs("a", "b", "other")
+	blobs[2].ContentType = "Audit.Exchange"
+	err := tn.deadLetters.add(
+		newEventDeadLetter("t", blobs[0], common.MapStr{"Operation": "A"}, "no CreationTime"),
+		newEventDeadLetter("t", blobs[0], common.MapStr{"Operation": "B"}, "no CreationTime"),
+		newBlobDeadLetter("t", blobs[1], errTest),
+		newBlobDeadLetter("t", blobs[2], errTest),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bt := &Replay{options: ReplayOptions{ContentTypes: []string{"Audit.General"}}}
+	if err := bt.replay(context.Background(), tn, tn.deadLetters.list()); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(client.published(), ""); got != "ba" {
+		t.Errorf("replayed %v, want ba", got)
+	}
+	for _, evt := range client.events {
+		if p := evt.Private.(*pendingBlob); p.blob.ContentID == "a" && !evt.Timestamp.Equal(blobs[0].ContentCreated) {
+			t.Errorf("got timestamp %v, want a's contentCreated", evt.Timestamp)
+		}
+	}
+	if got := len(tn.deadLetters.list()); got != 4 {
+		t.Errorf("removed dead letters before they were acknowledged, %v left", got)
+	}
+	tn.registrar.ackEvents(client.privates("a"))
+	tn.registrar.ackEvents(client.privates("b"))
+	if got := tn.deadLetters.list(); len(got) != 1 || got[0].ContentID != "other" {
+		t.Errorf("after acknowledging the replay: got %v", keys(got))
+	}
+}
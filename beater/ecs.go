@@ -0,0 +1,221 @@
+package beater
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/common"
+
+	"github.com/counteractive/o365beat/config"
+)
+
+// ecsMapper adds Elastic Common Schema fields to an event, derived from its
+// API fields (https://www.elastic.co/guide/en/ecs/current/index.html)
+type ecsMapper func(evt common.MapStr)
+
+// ecsMappings are the ECS mapping versions (ecs.mapping), so the mapping can
+// evolve without changing the fields of existing deployments until they opt in.
+// events record the version they were mapped with in o365.ecs_mapping.
+var ecsMappings = map[int]ecsMapper{
+	1: mapECSv1,
+}
+
+// newECSMapper returns the configured ECS mapping, nil if it's disabled
+func newECSMapper(c config.ECSConfig) (ecsMapper, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+	mapper, ok := ecsMappings[c.Mapping]
+	if !ok {
+		return nil, fmt.Errorf("ecs.mapping %v doesn't exist (the latest is %v)", c.Mapping, config.LatestECSMapping)
+	}
+	return func(evt common.MapStr) {
+		mapper(evt)
+		evt.Put("o365.ecs_mapping", c.Mapping)
+	}, nil
+}
+
+// ecsWorkloads add the workload-specific fields of mapping version 1, keyed by
+// the Workload field
+var ecsWorkloads = map[string]func(evt common.MapStr, related *relatedFields){
+	"AzureActiveDirectory": mapECSAzureActiveDirectory,
+	"Exchange":             mapECSExchange,
+	"SharePoint":           mapECSFiles,
+	"OneDrive":             mapECSFiles,
+}
+
+// mapECSv1 maps the common schema, then the event's workload
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-schema#common-schema
+func mapECSv1(evt common.MapStr) {
+	related := &relatedFields{}
+	evt.Put("event.kind", "event")
+	putString(evt, "event.id", evt["Id"])
+	putString(evt, "event.code", evt["RecordType"])
+	putString(evt, "event.action", evt["Operation"])
+	putString(evt, "event.provider", evt["Workload"])
+	if outcome := ecsOutcome(evt["ResultStatus"]); outcome != "" {
+		evt.Put("event.outcome", outcome)
+	}
+	evt.Put("cloud.provider", "azure")
+	putString(evt, "cloud.account.id", evt["OrganizationId"])
+
+	if userID, ok := evt["UserId"].(string); ok && userID != "" {
+		evt.Put("user.id", userID)
+		if i := strings.LastIndex(userID, "@"); i > 0 {
+			evt.Put("user.name", userID[:i])
+			evt.Put("user.domain", userID[i+1:])
+			evt.Put("user.email", userID)
+		}
+		related.user(userID)
+	}
+
+	// the client address is in different fields in some workloads
+	for _, field := range []string{"ClientIP", "ClientIPAddress", "ActorIpAddress"} {
+		if ip, port, ok := parseClientAddress(evt[field]); ok {
+			for _, prefix := range []string{"client", "source"} {
+				evt.Put(prefix+".ip", ip)
+				if port != 0 {
+					evt.Put(prefix+".port", port)
+				}
+			}
+			related.ip(ip)
+			break
+		}
+	}
+
+	if workload, ok := evt["Workload"].(string); ok {
+		if mapWorkload, ok := ecsWorkloads[workload]; ok {
+			mapWorkload(evt, related)
+		}
+	}
+	related.put(evt)
+}
+
+// mapECSAzureActiveDirectory maps azure ad (including STS logon) events
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-schema#azure-active-directory-base-schema
+func mapECSAzureActiveDirectory(evt common.MapStr, related *relatedFields) {
+	switch evt["Operation"] {
+	case "UserLoggedIn", "UserLoginFailed":
+		evt.Put("event.category", "authentication")
+		if evt["Operation"] == "UserLoginFailed" {
+			// ResultStatus only reports whether the http request succeeded
+			evt.Put("event.outcome", "failure")
+		}
+	default:
+		evt.Put("event.category", "iam")
+	}
+	if target, ok := evt["Target"].([]interface{}); ok {
+		for _, t := range target {
+			if m, ok := t.(map[string]interface{}); ok {
+				if id, ok := m["ID"].(string); ok && strings.Contains(id, "@") {
+					related.user(id)
+				}
+			}
+		}
+	}
+}
+
+// mapECSExchange maps exchange admin and mailbox events
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-schema#exchange-mailbox-schema
+func mapECSExchange(evt common.MapStr, related *relatedFields) {
+	if owner, ok := evt["MailboxOwnerUPN"].(string); ok && owner != "" {
+		related.user(owner)
+	}
+}
+
+// mapECSFiles maps sharepoint and onedrive events
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-schema#sharepoint-file-operations
+func mapECSFiles(evt common.MapStr, related *relatedFields) {
+	if _, ok := evt["SourceFileName"]; ok {
+		evt.Put("event.category", "file")
+	}
+	if target, ok := evt["TargetUserOrGroupName"].(string); ok && target != "" {
+		related.user(target)
+	}
+}
+
+// ecsOutcome maps ResultStatus (Succeeded, PartiallySucceeded or Failed, or True
+// or False for exchange admin events) to event.outcome
+func ecsOutcome(status interface{}) string {
+	s, ok := status.(string)
+	if !ok || s == "" {
+		return ""
+	}
+	switch strings.ToLower(s) {
+	case "succeeded", "success", "true":
+		return "success"
+	case "failed", "failure", "false":
+		return "failure"
+	}
+	return "unknown"
+}
+
+// parseClientAddress parses the API's client addresses: IPv4 or IPv6, with or
+// without a port (e.g., "1.2.3.4", "1.2.3.4:5678", "[::1]:5678", "::1")
+func parseClientAddress(v interface{}) (string, int, bool) {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return "", 0, false
+	}
+	if ip := net.ParseIP(strings.Trim(s, "[]")); ip != nil {
+		return ip.String(), 0, true
+	}
+	host, portStr, err := net.SplitHostPort(s)
+	if err != nil {
+		return "", 0, false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return "", 0, false
+	}
+	port, _ := strconv.Atoi(portStr)
+	return ip.String(), port, true
+}
+
+// putString puts v (a string or number) at key as a string, if it's set
+func putString(evt common.MapStr, key string, v interface{}) {
+	switch v := v.(type) {
+	case string:
+		if v != "" {
+			evt.Put(key, v)
+		}
+	case float64:
+		evt.Put(key, strconv.FormatFloat(v, 'f', -1, 64))
+	case int, int64:
+		evt.Put(key, fmt.Sprint(v))
+	}
+}
+
+// relatedFields collects the related.* values of an event, without duplicates
+type relatedFields struct {
+	ips   []string
+	users []string
+}
+
+func (r *relatedFields) ip(ip string) {
+	r.ips = appendUnique(r.ips, ip)
+}
+
+func (r *relatedFields) user(user string) {
+	r.users = appendUnique(r.users, user)
+}
+
+func (r *relatedFields) put(evt common.MapStr) {
+	if len(r.ips) > 0 {
+		evt.Put("related.ip", r.ips)
+	}
+	if len(r.users) > 0 {
+		evt.Put("related.user", r.users)
+	}
+}
+
+func appendUnique(values []string, v string) []string {
+	for _, existing := range values {
+		if existing == v {
+			return values
+		}
+	}
+	return append(values, v)
+}
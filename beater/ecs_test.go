@@ -0,0 +1,89 @@
+// +build !integration
+
+package beater
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestParseClientAddress(t *testing.T) {
+	tests := []struct {
+		address interface{}
+		ip      string
+		port    int
+		ok      bool
+	}{
+		{"1.2.3.4", "1.2.3.4", 0, true},
+		{"1.2.3.4:80", "1.2.3.4", 80, true},
+		{"::1", "::1", 0, true},
+		{"[::1]", "::1", 0, true},
+		{"[::1]:5678", "::1", 5678, true},
+		{"2001:db8::68", "2001:db8::68", 0, true},
+		{"[2001:db8::68]:443", "2001:db8::68", 443, true},
+		{"", "", 0, false},
+		{"host.example.com:80", "", 0, false},
+		{"not an address", "", 0, false},
+		{nil, "", 0, false},
+		{float64(1), "", 0, false},
+	}
+	for _, test := range tests {
+		ip, port, ok := parseClientAddress(test.address)
+		if ip != test.ip || port != test.port || ok != test.ok {
+			t.Errorf("%v: got %q, %v, %v, want %q, %v, %v", test.address, ip, port, ok, test.ip, test.port, test.ok)
+		}
+	}
+}
+
+func TestECSOutcome(t *testing.T) {
+	tests := map[interface{}]string{
+		"Succeeded":          "success",
+		"True":               "success",
+		"Failed":             "failure",
+		"False":              "failure",
+		"PartiallySucceeded": "unknown",
+		"":                   "",
+		nil:                  "",
+	}
+	for status, want := range tests {
+		if got := ecsOutcome(status); got != want {
+			t.Errorf("%v: got %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestMapECSv1(t *testing.T) {
+	evt := common.MapStr{
+		"Id":             "80c76bd2-9d81-4c57-a97a-accfc3443dca",
+		"RecordType":     float64(15),
+		"Operation":      "UserLoginFailed",
+		"Workload":       "AzureActiveDirectory",
+		"ResultStatus":   "Succeeded",
+		"OrganizationId": "d",
+		"UserId":         "alice@example.com",
+		"ClientIP":       "[2001:db8::68]:443",
+		"Target":         []interface{}{map[string]interface{}{"ID": "bob@example.com"}},
+	}
+	mapECSv1(evt)
+	want := map[string]interface{}{
+		"event.id":         "80c76bd2-9d81-4c57-a97a-accfc3443dca",
+		"event.code":       "15",
+		"event.action":     "UserLoginFailed",
+		"event.category":   "authentication",
+		"event.outcome":    "failure",
+		"cloud.account.id": "d",
+		"user.name":        "alice",
+		"user.domain":      "example.com",
+		"source.ip":        "2001:db8::68",
+		"source.port":      443,
+		"related.ip":       []string{"2001:db8::68"},
+		"related.user":     []string{"alice@example.com", "bob@example.com"},
+	}
+	for key, value := range want {
+		if got, _ := evt.GetValue(key); !reflect.DeepEqual(got, value) {
+			t.Errorf("%v: got %#v, want %#v", key, got, value)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package beater
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+
+	"github.com/counteractive/o365beat/config"
+)
+
+// enumTable names the values of the API's enum fields, keyed by field and then
+// value (e.g., "RecordType" -> "15" -> "AzureActiveDirectoryStsLogon")
+type enumTable map[string]map[string]string
+
+// enums is the built-in enum table, which enums.path can extend or override
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-schema#enum-auditlogrecordtype---type-edmint32
+var enums = enumTable{
+	"RecordType": {
+		"1":  "ExchangeAdmin",
+		"2":  "ExchangeItem",
+		"3":  "ExchangeItemGroup",
+		"4":  "SharePoint",
+		"6":  "SharePointFileOperation",
+		"8":  "AzureActiveDirectory",
+		"9":  "AzureActiveDirectoryAccountLogon",
+		"10": "DataCenterSecurityCmdlet",
+		"11": "ComplianceDLPSharePoint",
+		"12": "Sway",
+		"13": "ComplianceDLPExchange",
+		"14": "SharePointSharingOperation",
+		"15": "AzureActiveDirectoryStsLogon",
+		"16": "SkypeForBusinessPSTNUsage",
+		"17": "SkypeForBusinessUsersBlocked",
+		"18": "SecurityComplianceCenterEOPCmdlet",
+		"19": "ExchangeAggregatedOperation",
+		"20": "PowerBIAudit",
+		"21": "CRM",
+		"22": "Yammer",
+		"23": "SkypeForBusinessCmdlets",
+		"24": "Discovery",
+		"25": "MicrosoftTeams",
+		"28": "ThreatIntelligence",
+		"29": "MailSubmission",
+		"30": "MicrosoftFlow",
+		"31": "AeD",
+		"32": "MicrosoftStream",
+		"33": "ComplianceDLPSharePointClassification",
+		"34": "ThreatFinder",
+		"35": "Project",
+		"36": "SharePointListOperation",
+		"37": "SharePointCommentOperation",
+		"38": "DataGovernance",
+		"39": "Kaizala",
+		"40": "SecurityComplianceAlerts",
+		"41": "ThreatIntelligenceUrl",
+		"42": "SecurityComplianceInsights",
+		"43": "MIPLabel",
+		"44": "WorkplaceAnalytics",
+		"45": "PowerAppsApp",
+		"46": "PowerAppsPlan",
+		"47": "ThreatIntelligenceAtpContent",
+		"48": "LabelContentExplorer",
+		"49": "TeamsHealthcare",
+		"50": "ExchangeItemAggregated",
+		"51": "HygieneEvent",
+		"52": "DataInsightsRestApiAudit",
+		"53": "InformationBarrierPolicyApplication",
+		"54": "SharePointListItemOperation",
+		"55": "SharePointContentTypeOperation",
+		"56": "SharePointFieldOperation",
+		"57": "MicrosoftTeamsAdmin",
+		"58": "HRSignal",
+		"59": "MicrosoftTeamsDevice",
+		"60": "MicrosoftTeamsAnalytics",
+		"61": "InformationWorkerProtection",
+		"62": "Campaign",
+		"63": "DLPEndpoint",
+		"64": "AirInvestigation",
+		"65": "Quarantine",
+		"66": "MicrosoftForms",
+		"67": "ApplicationAudit",
+		"68": "ComplianceSupervisionExchange",
+		"69": "CustomerKeyServiceEncryption",
+		"70": "OfficeNative",
+		"71": "MipAutoLabelSharePointItem",
+		"72": "MipAutoLabelSharePointPolicyLocation",
+		"73": "MicrosoftTeamsShifts",
+	},
+	// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-schema#enum-user-type---type-edmint32
+	"UserType": {
+		"0": "Regular",
+		"1": "Reserved",
+		"2": "Admin",
+		"3": "DcAdmin",
+		"4": "System",
+		"5": "Application",
+		"6": "ServicePrincipal",
+		"7": "CustomPolicy",
+		"8": "SystemPolicy",
+	},
+	// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-schema#auditlogscope
+	"Scope": {
+		"0": "Online",
+		"1": "Onprem",
+	},
+	// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-schema#enum-logontype---type-edmint32
+	"LogonType": {
+		"0": "Owner",
+		"1": "Admin",
+		"2": "Delegated",
+		"3": "Transport",
+		"4": "SystemService",
+		"5": "BestAccess",
+		"6": "DelegatedAdmin",
+	},
+	"InternalLogonType": {
+		"0": "Owner",
+		"1": "Admin",
+		"2": "Delegated",
+		"3": "Transport",
+		"4": "SystemService",
+		"5": "BestAccess",
+		"6": "DelegatedAdmin",
+	},
+	// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-schema#enum-azureactivedirectoryeventtype---type-edmint32
+	"AzureActiveDirectoryEventType": {
+		"0": "AccountLogon",
+		"1": "AzureApplicationAuditEvent",
+	},
+}
+
+// enumEnricher adds the names of events' enum values as o365.<field>_name
+// (e.g., o365.record_type_name), leaving values it doesn't know as they are
+type enumEnricher struct {
+	table enumTable
+	names map[string]string // o365.<field>_name for each field
+}
+
+// newEnumEnricher returns an enricher with the built-in table, extended by the
+// json file at enums.path (in the same form as enumTable), nil if disabled
+func newEnumEnricher(c config.EnumsConfig) (*enumEnricher, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+	table := enumTable{}
+	for field, values := range enums {
+		table[field] = map[string]string{}
+		for v, name := range values {
+			table[field][v] = name
+		}
+	}
+	if c.Path != "" {
+		data, err := ioutil.ReadFile(c.Path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading enums.path: %v", err)
+		}
+		var extra enumTable
+		if err := json.Unmarshal(data, &extra); err != nil {
+			return nil, fmt.Errorf("error decoding enums.path %v: %v", c.Path, err)
+		}
+		for field, values := range extra {
+			if table[field] == nil {
+				table[field] = map[string]string{}
+			}
+			for v, name := range values {
+				table[field][v] = name
+			}
+		}
+	}
+	e := &enumEnricher{table: table, names: map[string]string{}}
+	for field := range table {
+		e.names[field] = "o365." + snakeCase(field) + "_name"
+	}
+	return e, nil
+}
+
+// enrich adds the names of evt's enum values, counting values it doesn't know
+func (e *enumEnricher) enrich(evt common.MapStr) {
+	for field, values := range e.table {
+		raw, ok := evt[field]
+		if !ok {
+			continue
+		}
+		var value string
+		switch v := raw.(type) {
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		case string:
+			value = v
+		default:
+			continue
+		}
+		if name, ok := values[value]; ok {
+			evt.Put(e.names[field], name)
+			continue
+		}
+		if name, ok := e.nameOf(values, value); ok {
+			// e.g., a string enum whose values are already names
+			evt.Put(e.names[field], name)
+			continue
+		}
+		countUnknownEnum(field, value)
+	}
+}
+
+// nameOf reports whether value is one of the enum's names itself
+func (e *enumEnricher) nameOf(values map[string]string, value string) (string, bool) {
+	for _, name := range values {
+		if strings.EqualFold(name, value) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// unknownEnums counts unknown values of each enum field (o365beat.enums.unknown.<field>)
+var (
+	unknownEnumsMutex    sync.Mutex
+	unknownEnums         = map[string]*monitoring.Int{}
+	unknownEnumsRegistry = metrics.NewRegistry("enums").NewRegistry("unknown")
+)
+
+func countUnknownEnum(field, value string) {
+	unknownEnumsMutex.Lock()
+	defer unknownEnumsMutex.Unlock()
+	counter, ok := unknownEnums[field]
+	if !ok {
+		counter = monitoring.NewInt(unknownEnumsRegistry, snakeCase(field), monitoring.Report)
+		unknownEnums[field] = counter
+	}
+	if counter.Inc() == 1 {
+		logp.Info("unknown %v value %v (add it with enums.path), counting further unknown values in metrics", field, value)
+	}
+}
+
+// snakeCase converts a field name like RecordType to record_type
+func snakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// start a word at an upper case letter after a lower case one, or before one (e.g., "DLPEndpoint")
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
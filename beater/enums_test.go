@@ -0,0 +1,81 @@
+// +build !integration
+
+package beater
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastic/beats/libbeat/common"
+
+	"github.com/counteractive/o365beat/config"
+)
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"RecordType":                    "record_type",
+		"UserType":                      "user_type",
+		"Scope":                         "scope",
+		"DLPEndpoint":                   "dlp_endpoint",
+		"AzureActiveDirectoryEventType": "azure_active_directory_event_type",
+		"InternalLogonType":             "internal_logon_type",
+		"ID":                            "id",
+		"lower":                         "lower",
+	}
+	for s, want := range tests {
+		if got := snakeCase(s); got != want {
+			t.Errorf("%v: got %q, want %q", s, got, want)
+		}
+	}
+}
+
+func TestEnumEnricher(t *testing.T) {
+	e, err := newEnumEnricher(config.EnumsConfig{Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	evt := common.MapStr{
+		"RecordType": float64(15),
+		"UserType":   float64(2),
+		"Scope":      "online", // some workloads send the name itself
+		"LogonType":  float64(999),
+	}
+	e.enrich(evt)
+	want := map[string]interface{}{
+		"o365.record_type_name": "AzureActiveDirectoryStsLogon",
+		"o365.user_type_name":   "Admin",
+		"o365.scope_name":       "Online",
+		"o365.logon_type_name":  nil,
+	}
+	for key, value := range want {
+		if got, _ := evt.GetValue(key); got != value {
+			t.Errorf("%v: got %v, want %v", key, got, value)
+		}
+	}
+}
+
+func TestEnumEnricherPath(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "enums.json")
+	if err := ioutil.WriteFile(path, []byte(`{"RecordType":{"15":"Renamed","999":"New"},"DLPEndpoint":{"1":"One"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	e, err := newEnumEnricher(config.EnumsConfig{Enabled: true, Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for value, want := range map[float64]string{15: "Renamed", 999: "New", 2: "ExchangeItem"} {
+		evt := common.MapStr{"RecordType": value}
+		e.enrich(evt)
+		if got, _ := evt.GetValue("o365.record_type_name"); got != want {
+			t.Errorf("RecordType %v: got %v, want %v", value, got, want)
+		}
+	}
+	evt := common.MapStr{"DLPEndpoint": float64(1)}
+	e.enrich(evt)
+	if got, _ := evt.GetValue("o365.dlp_endpoint_name"); got != "One" {
+		t.Errorf("got %v for a field added by enums.path", got)
+	}
+}
@@ -0,0 +1,52 @@
+package beater
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// registryLock is an exclusive lock on the registry, held by a running beat or a
+// registry command that writes it.  it's an advisory lock (see lockFile) on a
+// file next to the registry, which the os releases when its process exits, so
+// a crashed beat doesn't leave a stale lock behind.  the file holds the owner's
+// pid, for the error message.
+type registryLock struct {
+	file *os.File
+}
+
+// errLocked is returned by lockFile when another process holds the lock
+var errLocked = errors.New("locked by another process")
+
+func lockRegistry(registryPath string) (*registryLock, error) {
+	path := registryPath + ".lock"
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		if err == errLocked {
+			owner, _ := ioutil.ReadFile(path)
+			return nil, fmt.Errorf("registry %v is locked by process %v (another o365beat or registry command is using it)", registryPath, strings.TrimSpace(string(owner)))
+		}
+		return nil, fmt.Errorf("error locking registry %v: %v", registryPath, err)
+	}
+	if err := f.Truncate(0); err == nil {
+		f.WriteAt([]byte(fmt.Sprintf("%d\n", os.Getpid())), 0)
+	}
+	return &registryLock{file: f}, nil
+}
+
+// unlock releases the lock.  the file is left in place: removing it could let
+// two processes lock different files at the same path.
+func (l *registryLock) unlock() {
+	l.file.Truncate(0)
+	if err := l.file.Close(); err != nil {
+		logp.Warn("error releasing registry lock %v: %v", l.file.Name(), err)
+	}
+}
@@ -0,0 +1,30 @@
+// +build !integration
+
+package beater
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLockRegistry(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "o365beat.state")
+
+	lock, err := lockRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lockRegistry(path); err == nil {
+		t.Fatal("locked a registry that's already locked")
+	}
+	lock.unlock()
+
+	// the lock file is left behind, but it's free
+	lock, err = lockRegistry(path)
+	if err != nil {
+		t.Fatalf("locking a released registry: %v", err)
+	}
+	lock.unlock()
+}
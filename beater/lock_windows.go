@@ -0,0 +1,34 @@
+//go:build windows
+// +build windows
+
+package beater
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var procLockFileEx = syscall.NewLazyDLL("kernel32.dll").NewProc("LockFileEx")
+
+const (
+	lockfileFailImmediately = 0x1
+	lockfileExclusiveLock   = 0x2
+
+	errorLockViolation syscall.Errno = 33
+)
+
+// lockFile takes an exclusive LockFileEx lock on f without waiting, released
+// when f is closed.  the locked byte is far past the pid written to the file,
+// so other processes can still read it.
+func lockFile(f *os.File) error {
+	ol := &syscall.Overlapped{OffsetHigh: 0x40000000}
+	r, _, err := procLockFileEx.Call(f.Fd(), lockfileExclusiveLock|lockfileFailImmediately, 0, 1, 0, uintptr(unsafe.Pointer(ol)))
+	if r != 0 {
+		return nil
+	}
+	if err == errorLockViolation {
+		return errLocked
+	}
+	return err
+}
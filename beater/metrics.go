@@ -0,0 +1,61 @@
+package beater
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+// metrics holds the beat's own metrics (o365beat.* in the metrics log and
+// monitoring data)
+var metrics = monitoring.Default.NewRegistry("o365beat")
+
+// event counters (o365beat.events.*)
+var (
+	eventMetrics       = metrics.NewRegistry("events")
+	timestampFallbacks = monitoring.NewInt(eventMetrics, "timestamp_fallbacks", monitoring.Report) // events published with timestamp_fallback
+	deadLettered       = monitoring.NewInt(eventMetrics, "dead_lettered", monitoring.Report)       // events kept in the dead-letter store
+)
+
+// metricsTenants are the running tenants, reported by reportTokenMetrics
+var (
+	metricsTenantsMutex sync.Mutex
+	metricsTenants      []*tenant
+)
+
+func init() {
+	monitoring.NewFunc(metrics, "auth", reportTokenMetrics, monitoring.Report)
+}
+
+// setMetricsTenants sets the tenants whose metrics are reported
+func setMetricsTenants(tenants []*tenant) {
+	metricsTenantsMutex.Lock()
+	defer metricsTenantsMutex.Unlock()
+	metricsTenants = tenants
+}
+
+// reportTokenMetrics reports each tenant's api token stats, keyed by its
+// registry namespace (the directory id by default)
+func reportTokenMetrics(m monitoring.Mode, V monitoring.Visitor) {
+	metricsTenantsMutex.Lock()
+	defer metricsTenantsMutex.Unlock()
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+
+	now := time.Now()
+	for _, t := range metricsTenants {
+		stats := t.api.TokenStats()
+		var expiresIn time.Duration
+		if !stats.ExpiresOn.IsZero() {
+			expiresIn = stats.ExpiresOn.Sub(now)
+		}
+		monitoring.ReportNamespace(V, t.config.RegistryNamespace, func() {
+			monitoring.ReportInt(V, "token_age_seconds", int64(stats.Age(now)/time.Second))
+			monitoring.ReportInt(V, "token_expires_in_seconds", int64(expiresIn/time.Second))
+			monitoring.ReportInt(V, "token_refreshes", stats.Refreshes)
+			monitoring.ReportInt(V, "token_cache_hits", stats.CacheHits)
+			monitoring.ReportInt(V, "token_failures", stats.Failures)
+		})
+	}
+}
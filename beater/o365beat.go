@@ -1,24 +1,14 @@
 package beater
 
 import (
-	"crypto/rsa"
-	"crypto/x509"
-	"encoding/json"
+	"context"
 	"fmt"
-	"github.com/Azure/go-autorest/autorest/adal"
+	"sync"
+	"time"
+
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/logp"
-	"golang.org/x/crypto/pkcs12"
-	"gopkg.in/oleiade/reflections.v1"
-	"io/ioutil"
-	"log"
-	"net/http"
-	"net/url"
-	"sort"
-	"strconv"
-	"strings"
-	"time"
 
 	// import o365beat-level processors (same style as filebeat)
 	_ "github.com/elastic/beats/libbeat/processors/script"
@@ -26,56 +16,23 @@ import (
 	"github.com/counteractive/o365beat/config"
 )
 
-// authInfo holds information returned by the microsoft oauth API
-// (see https://docs.microsoft.com/en-us/office/office-365-management-api/get-started-with-office-365-management-apis#sample-response)
-type authInfo struct {
-	TokenType   string `json:"token_type"`
-	ExpiresIn   string `json:"expires_in"`
-	ExpiresOn   string `json:"expires_on"`
-	NotBefore   string `json:"not_before"`
-	Resource    string `json:"resource"`
-	AccessToken string `json:"access_token"`
-}
-
-
-func decodePkcs12(pkcs []byte, password string) (*x509.Certificate, *rsa.PrivateKey, error) {
-	privateKey, certificate, err := pkcs12.Decode(pkcs, password)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	rsaPrivateKey, isRsaKey := privateKey.(*rsa.PrivateKey)
-	if !isRsaKey {
-		return nil, nil, fmt.Errorf("PKCS#12 certificate must contain an RSA private key")
-	}
-
-	return certificate, rsaPrivateKey, nil
-}
-
-
-func (a *authInfo) header() string {
-	return fmt.Sprintf("%s %s", a.TokenType, a.AccessToken)
-}
-
-func (a *authInfo) expired() bool {
-	const expirationBuffer = 60 // extra seconds unexpired token is considered expired
-	expiresOn, _ := strconv.ParseInt(a.ExpiresOn, 10, 64)
-	return time.Now().Unix() > (expiresOn - expirationBuffer)
-}
-
 // O365beat configuration and state.
 type O365beat struct {
-	done       chan struct{} // channel to initiate shutdown of main event loop
-	config     config.Config // configuration settings
-	client     beat.Client
-	authURL    string // oauth authentication url built from config
-	apiRootURL string // api root url built from config
-	httpClient *http.Client
-	auth       *authInfo
+	done          chan struct{} // channel to initiate shutdown of main event loop
+	config        config.Config // configuration settings
+	tenantConfigs []config.TenantConfig
+	tenants       []*tenant  // one per tenant, each polling concurrently
+	registrar     *registrar // advances the registry as events are acknowledged
+	store         Registry   // where the registry is kept (registry.type)
+	deadLetters   *deadLetterStore
 }
 
 // New creates an instance of o365beat.
 func New(b *beat.Beat, cfg *common.Config) (beat.Beater, error) {
+	return newO365beat(cfg)
+}
+
+func newO365beat(cfg *common.Config) (*O365beat, error) {
 	c := config.DefaultConfig
 	if err := cfg.Unpack(&c); err != nil {
 		err = fmt.Errorf("Error reading config file: %v", err)
@@ -83,528 +40,180 @@ func New(b *beat.Beat, cfg *common.Config) (beat.Beater, error) {
 		return nil, err
 	}
 
-	// using url.Parse seems like overkill
-	loginURL := c.LoginURL
-	resourceURL := c.ResourceURL
-	au := loginURL + "/" + c.TenantDomain + "/oauth2/token?api-version=1.0"
-	api := resourceURL + "/api/v1.0/" + c.DirectoryID + "/activity/feed/"
-	cl := &http.Client{Timeout: c.APITimeout}
-	var ai *authInfo
-
-	bt := &O365beat{
-		done:       make(chan struct{}),
-		config:     c,
-		authURL:    au,
-		apiRootURL: api,
-		httpClient: cl,
-		auth:       ai,
-	}
-	return bt, nil
-}
-
-// apiRequest issues an http request with api authorization header
-func (bt *O365beat) apiRequest(verb, urlStr string, body, query, headers map[string]string) (*http.Response, error) {
-	reqBody := url.Values{}
-	for k, v := range body {
-		reqBody.Set(k, v)
-	}
-	req, err := http.NewRequest(verb, urlStr, strings.NewReader(reqBody.Encode()))
+	tenants, err := c.TenantConfigs()
 	if err != nil {
+		err = fmt.Errorf("Error reading config file: %v", err)
 		logp.Error(err)
 		return nil, err
 	}
-	reqQuery := req.URL.Query()                                // keep querystring values from urlStr
-	reqQuery.Set("PublisherIdentifier", bt.config.DirectoryID) // to prevent throttling
-	for k, v := range query {
-		reqQuery.Set(k, v)
-	}
-	req.URL.RawQuery = reqQuery.Encode()
-	for k, v := range headers {
-		req.Header.Set(k, v)
-	}
-	// refresh authentication if expired
-	if bt.auth == nil || bt.auth.expired() {
-		logp.Info("auth nil or expired, re-authenticating")
-		err = bt.authenticate()
-		if err != nil {
-			logp.Error(err)
-			return nil, err
-		}
-	}
-	req.Header.Set("Authorization", bt.auth.header())
-
-	logp.Debug("api", "issuing api request: %s", req.URL.String())
-	res, err := bt.httpClient.Do(req)
-	if err != nil {
-		logp.Error(err)
-		return nil, err
-	} else if res.StatusCode != 200 {
-		// TODO: handle errors reading response body (previously overwritten by next line)
-		body, _ := ioutil.ReadAll(res.Body)
-		err = fmt.Errorf("non-200 status during api request.\n\tnewly enabled or newly subscribed feeds can take 12 hours or more to provide data.\n\tconfirm audit log searching is enabled for the target tenancy (https://docs.microsoft.com/en-us/microsoft-365/compliance/turn-audit-log-search-on-or-off#turn-on-audit-log-search).\n\treq: %v\n\tres: %v\n\t%v", req, res, string(body))
-		logp.Error(err)
-		return nil, err
-	}
-	return res, nil
-}
 
-func (bt *O365beat) callback(token adal.Token) error {
-	// body
-	return nil;
-}
-
-// authenticate retrieves oauth2 information using client id and client_secret for use with the API
-// https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-client-creds-grant-flow
-func (bt *O365beat) authenticate() error {
-	if bt.config.ClientSecret == "" && bt.config.CertificatePath !="" && bt.config.CertificatePwd !="" {
-		const activeDirectoryEndpoint = "https://login.microsoftonline.com/"
-		//tenantID := "b0f86485-3e24-4eef-b1c3-337085cc000f"
-		tenantID := bt.config.DirectoryID
-		oauthConfigPointer, err := adal.NewOAuthConfig(activeDirectoryEndpoint, tenantID)
-		oauthConfig:=*oauthConfigPointer
-		applicationID := bt.config.ClientID
-		// The resource for which the token is acquired
-		resource := bt.config.ResourceURL
-		certificatePath := bt.config.CertificatePath
-		certData, err := ioutil.ReadFile(certificatePath)
-		if err != nil {
-			return fmt.Errorf("failed to read the certificate file (%s): %v", certificatePath, err)
-		}
-		// Get the certificate and private key from pfx file
-		certificate, rsaPrivateKey, err :=  decodePkcs12(certData, bt.config.CertificatePwd)
-		if err != nil {
-			return fmt.Errorf("failed to decode pkcs12 certificate while creating spt: %v", err)
-		}
-		//Set up the configuration of the service principal
-		spt, err := adal.NewServicePrincipalTokenFromCertificate(
-			oauthConfig,
-			applicationID,
-			certificate,
-			rsaPrivateKey,
-			resource,
-			bt.callback,
-		)
-		// acquires the token
-		err  = spt.Refresh()
-		var token adal.Token
-		if err == nil {
-			token = spt.Token()
-			logp.Info("token successfully acquired")
-		} else {
-			logp.Error(err)
-		}
-		var tokenValues [6]string
-		fieldsToExtract := []string{"AccessToken", "ExpiresIn", "ExpiresOn", "NotBefore", "Resource", "Type"}
-		//fieldsToExtract := []string{ "Type", "ExpiresIn", "NotBefore", "ExpiresOn",  "Resource", "AccessToken"}
-		for index, fieldName := range fieldsToExtract {
-			value, err := reflections.GetField(token, fieldName)
-			if err != nil {
-				logp.Error(err)
-			}
-			tokenValues[index]=fmt.Sprint(value)
-		}
-		//for debugging
-		//for _, str := range tokenValues {
-		//	fmt.Printf("* %s\n", str)
-		//}
-		var ai authInfo
-		aip := &ai
-		aip.AccessToken=tokenValues[0]
-		aip.ExpiresIn=tokenValues[1]
-		aip.ExpiresOn=tokenValues[2]
-		aip.NotBefore=tokenValues[3]
-		aip.Resource=tokenValues[4]
-		aip.TokenType=tokenValues[5]
-		//fields := reflect.TypeOf(ai)
-		//values := reflect.ValueOf(ai)
-		//for i := 0; i < fields.NumField(); i++ {
-		//	values.Field(i).SetString(tokenValues[i])
-		//}
-		//aip := &ai
-		bt.auth=aip
-	} else if bt.config.ClientSecret != "" && bt.config.CertificatePath =="" && bt.config.CertificatePwd ==""{
-		logp.Info("authenticating via %s", bt.authURL)
-		reqBody := url.Values{}
-		reqBody.Set("grant_type", "client_credentials")
-		reqBody.Set("resource", bt.config.ResourceURL)
-		reqBody.Set("client_id", bt.config.ClientID)
-		reqBody.Set("client_secret", bt.config.ClientSecret)
-		req, err := http.NewRequest("POST", bt.authURL, strings.NewReader(reqBody.Encode()))
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		logp.Debug("auth", "sending auth req: %v", req)
-		res, err := bt.httpClient.Do(req)
-		if err != nil {
-			logp.Error(err)
-			return err
-		} else if res.StatusCode != 200 {
-			// TODO: handle errors reading response body:
-			body, _ := ioutil.ReadAll(res.Body)
-			err = fmt.Errorf("non-200 status during auth.\n\tcheck client secret and other config details.\n\treq: %v\n\tres: %v\n\t%v", req, res, string(body))
-			logp.Error(err)
-			return err
-		}
-		defer res.Body.Close()
-		var ai authInfo
-		json.NewDecoder(res.Body).Decode(&ai)
-		logp.Debug("auth", "got auth info: %v", ai)
-		bt.auth = &ai
-	} else {
-		log.Fatal("fatal error: please enter your authentication credentials using either a client secret or a certificate")
+	bt := &O365beat{
+		done:          make(chan struct{}),
+		config:        c,
+		tenantConfigs: tenants,
 	}
-	return nil
+	return bt, nil
 }
 
-// listSubscriptions gets a collection of the current subscriptions and associated webhooks
-// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#list-current-subscriptions
-func (bt *O365beat) listSubscriptions() ([]map[string]string, error) {
-	logp.Info("getting content subscriptions")
-	logp.Debug("api", "getting content subscriptions from %v", bt.apiRootURL+"subscriptions/list")
-	res, err := bt.apiRequest("GET", bt.apiRootURL+"subscriptions/list", nil, nil, nil)
-	if err != nil {
-		logp.Error(err)
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	var subs []map[string]string
-	json.NewDecoder(res.Body).Decode(&subs)
-	logp.Debug("api", "got these subscriptions: %v", subs)
-	return subs, nil
-}
+// Run starts o365beat.
+func (bt *O365beat) Run(b *beat.Beat) error {
+	logp.Info("o365beat is running! Hit CTRL-C to stop it.")
 
-// subscribe starts a subscription to the specified content type
-// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#start-a-subscription
-func (bt *O365beat) subscribe(contentType string) (common.MapStr, error) {
-	logp.Info("subscribing to content type %v", contentType)
-	logp.Info("note that new subscriptions can take up to 12 hours to produce data")
-	logp.Debug("api", "subscribing to %v at %s", contentType, bt.apiRootURL+"subscriptions/start")
-	query := map[string]string{
-		"contentType": contentType,
-	}
-	res, err := bt.apiRequest("POST", bt.apiRootURL+"subscriptions/start", nil, query, nil)
+	// only one beat (or registry command) may use the registry at a time
+	lock, err := lockRegistry(bt.config.RegistryFilePath)
 	if err != nil {
 		logp.Error(err)
-		return nil, err
+		return err
 	}
-	defer res.Body.Close()
+	defer lock.unlock()
 
-	var sub common.MapStr
-	json.NewDecoder(res.Body).Decode(&sub)
-	logp.Debug("api", "got this subscription response: %v", sub)
-	return sub, nil
-}
-
-// enableSubscriptions enables subscriptions for all configured contentTypes
-func (bt *O365beat) enableSubscriptions() error {
-	logp.Info("enabling subscriptions for configured content types: %v", bt.config.ContentTypes)
-	subscriptions, err := bt.listSubscriptions()
+	bt.store, err = openRegistryStore(bt.config)
 	if err != nil {
 		logp.Error(err)
 		return err
 	}
-
-	// add subscriptions as "disabled" if not in listSubscription results (can return []!):
-	for _, t := range bt.config.ContentTypes {
-		found := false
-		for _, sub := range subscriptions {
-			if sub["contentType"] == t {
-				logp.Debug("api", "found subscription for contentType %s (enabled or disabled)", t)
-				found = true
-				break
-			}
-		}
-		if !found {
-			logp.Debug("api", "no subscription for configured contentType %s, appending to list to subscribe", t)
-			subscriptions = append(subscriptions, map[string]string{"contentType": t, "status": "disabled"})
-		}
-	}
-
-	for _, sub := range subscriptions {
-		if sub["status"] != "enabled" {
-			_, err := bt.subscribe(sub["contentType"])
-			if err != nil {
-				logp.Error(err)
-				return err
-			}
-		}
-	}
-	return nil
-}
-
-// listAvailableContent gets blob locations for a single content type over <=24 hour span
-// (the basic primitive provided by the API)
-// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#list-available-content
-func (bt *O365beat) listAvailableContent(contentType string, start, end time.Time) ([]map[string]string, error) {
-	logp.Info("getting available content of type %s between %s and %s", contentType, start, end)
-	logp.Debug(
-		"api", "getting available content from %s of type %s between %s and %s",
-		bt.apiRootURL+"subscriptions/content", contentType, start, end,
-	)
-	now := time.Now()
-	if now.Sub(start) > bt.config.ContentMaxAge {
-		logp.Warn("start (%v) must be <=%v hrs ago, resetting", start, bt.config.ContentMaxAge.Hours())
-		start = now.Add(-bt.config.ContentMaxAge)
-	}
-	if end.Sub(start).Hours() > 24 {
-		err := fmt.Errorf("start (%v) and end (%v) must be <=24 hrs apart", start, end)
+	defer bt.store.Close()
+	if err := bt.store.Lock(); err != nil {
 		logp.Error(err)
-		return nil, err
-	}
-	if end.Before(start) {
-		err := fmt.Errorf("start (%v) must be before end (%v)", start, end)
-		logp.Error(err)
-		return nil, err
+		return err
 	}
 
-	dateFmt := "2006-01-02T15:04:05" // API needs UTC in this format (no "Z" suffix)
-	query := map[string]string{
-		"contentType": contentType,
-		"startTime":   start.UTC().Format(dateFmt),
-		"endTime":     end.UTC().Format(dateFmt),
-	}
-	res, err := bt.apiRequest("GET", bt.apiRootURL+"subscriptions/content", nil, query, nil)
+	// registry (state) holds the most recent "contentCreated" for processed blobs
+	// of each tenant and content type; a cursor means that blob and all before have
+	// been published and acknowledged by the output.
+	reg, err := bt.getRegistry()
 	if err != nil {
 		logp.Error(err)
-		return nil, err
-	}
-
-	var locs []map[string]string
-	json.NewDecoder(res.Body).Decode(&locs)
-	res.Body.Close()
-	contentList := locs
-
-	for res.Header.Get("NextPageUri") != "" {
-		next := res.Header.Get("NextPageUri")
-		logp.Debug("api", "getting next page of results from NextPageUri: %v", next)
-		res, err = bt.apiRequest("GET", next, nil, nil, nil) // don't redeclare res!
-		if err != nil {
-			logp.Error(err)
-			return nil, err
-		}
-		json.NewDecoder(res.Body).Decode(&locs)
-		res.Body.Close()
-		contentList = append(contentList, locs...)
+		return err
 	}
-	logp.Info(
-		"got %v available content locations of type %s between %s and %s",
-		len(contentList), contentType, start, end,
-	)
-	logp.Debug("api", "got this available content: %v", contentList)
-	return contentList, nil
-}
+	bt.registrar = newRegistrar(reg, bt.config.ContentOverlap, bt.saveRegistry)
 
-// listAllAvailableContent gets blob locations for multiple content types and spans up to 7 days
-// sorted by contentCreated timestamp (uses the listAvailableContent function)
-func (bt *O365beat) listAllAvailableContent(start, end time.Time) ([]map[string]string, error) {
-	logp.Info("getting all available content between %s and %s", start, end)
-	logp.Debug(
-		"api", "getting all available content from %s between %s and %s",
-		bt.apiRootURL+"subscriptions/content", start, end,
-	)
-	if end.Before(start) {
-		err := fmt.Errorf("start (%v) must be before end (%v)", start, end)
+	// events (and blobs) that can't be published are kept rather than lost
+	bt.deadLetters, err = openDeadLetterStore(bt.config.DeadLetterPath)
+	if err != nil {
+		err = fmt.Errorf("error opening dead-letter store %v: %v", bt.config.DeadLetterPath, err)
 		logp.Error(err)
-		return nil, err
+		return err
 	}
 
-	interval := 24 * time.Hour
-	var contentList []map[string]string
-
-	// loop through intervals:
-	for iStart, iEnd := start, start; iStart.Before(end); iStart = iEnd {
-		iEnd = iStart.Add(interval)
-		if end.Before(iEnd) {
-			iEnd = end
-		}
+	// cancel in-flight api requests (and retry waits) on Stop
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-		// loop through all content types this interval:
-		for _, t := range bt.config.ContentTypes {
-			list, err := bt.listAvailableContent(t, iStart, iEnd)
-			if err != nil {
-				logp.Error(err)
-				return nil, err
+	// shutdown closes the pipeline clients, but if Run fails before then, they're closed here
+	closeClients := true
+	defer func() {
+		if closeClients {
+			for _, t := range bt.tenants {
+				t.client.Close()
 			}
-			contentList = append(contentList, list...)
-		}
-		// could start downloads here if concurrency is implemented
-		logp.Debug("api", "finished interval %v to %v", iStart, iEnd)
-	}
-	logp.Debug("api", "got these available content locations: %v", contentList)
-	less := func(i, j int) bool {
-		it, _ := time.Parse(time.RFC3339, contentList[i]["contentCreated"])
-		jt, _ := time.Parse(time.RFC3339, contentList[j]["contentCreated"])
-		return it.Before(jt)
-	}
-	sorted := sort.SliceIsSorted(contentList, less)
-	if !sorted {
-		logp.Debug("api", "available content locations were unsorted; sorting by creation time")
-		sort.SliceStable(contentList, less)
-	}
-	return contentList, nil
-}
-
-// getContent gets actual content blobs
-func (bt *O365beat) getContent(urlStr string) ([]common.MapStr, error) {
-	logp.Debug("api", "getting content from %v.", urlStr)
-	res, err := bt.apiRequest("GET", urlStr, nil, nil, nil)
-	if err != nil {
-		logp.Error(err)
-		return nil, err
-	}
-	var events []common.MapStr
-	json.NewDecoder(res.Body).Decode(&events)
-	res.Body.Close()
-	return events, nil
-}
-
-// publish sends events into the beats pipeline
-func (bt *O365beat) publish(content []common.MapStr, b *beat.Beat) error {
-	logp.Debug("beat", "publishing %v event(s)", len(content))
-	for _, evt := range content {
-		// event CreationTime needs "Z" appended (unlike blob contentCreated)
-		ts, err := time.Parse(time.RFC3339, evt["CreationTime"].(string)+"Z")
-		if err != nil {
-			logp.Error(err)
-			return err
-		}
-		fs := common.MapStr{}
-		for k, v := range evt {
-			fs[k] = v
 		}
-		beatEvent := beat.Event{Timestamp: ts, Fields: fs}
-		bt.client.Publish(beatEvent)
-	}
-	return nil
-}
+	}()
 
-func (bt *O365beat) poll(lastProcessed time.Time, b *beat.Beat) error {
-	logp.Debug("beat", "polling since %v", lastProcessed)
-	// start span just after last contentCreated or max bt.config.ContentMaxAge (default 7 days)
-	now := time.Now()
-	start := now.Add(-bt.config.ContentMaxAge)
-	if start.Before(lastProcessed) {
-		start = lastProcessed.Add(time.Second) // API granularity is by the second
-	}
-
-	// get all available content locations (sorted by contentCreated):
-	availableContent, err := bt.listAllAvailableContent(start, now)
-	if err != nil {
-		err = fmt.Errorf("error listing all available content between %v and %v: %v", start, now, err)
-		logp.Error(err)
-		return err
-	}
-
-	// get the actual content and publish it (concurrently someday?)
-	for _, v := range availableContent {
-		content, err := bt.getContent(v["contentUri"])
-		if err != nil {
-			logp.Warn("error getting content: %v, moving to next blob", err)
-			continue
-		}
-		err = bt.publish(content, b)
-		if err != nil {
-			logp.Error(err)
-			return err
-		}
-		contentCreated, err := time.Parse(time.RFC3339, v["contentCreated"])
+	for _, tc := range bt.tenantConfigs {
+		t, err := newTenant(bt.config, tc, bt.registrar, bt.deadLetters)
 		if err != nil {
 			logp.Error(err)
 			return err
 		}
-		logp.Debug("beat", "published blob created %v, last was %v, updating registry", contentCreated, lastProcessed)
-		err = bt.putRegistry(contentCreated)
+		// guaranteed publishing, so the registry only advances once events are delivered
+		t.client, err = b.Publisher.ConnectWith(beat.ClientConfig{
+			PublishMode: beat.GuaranteedSend,
+			ACKEvents:   bt.registrar.ackEvents,
+		})
 		if err != nil {
 			logp.Error(err)
 			return err
 		}
-		lastProcessed = contentCreated
+		bt.tenants = append(bt.tenants, t)
 	}
-	return nil
-}
 
-func (bt *O365beat) getRegistry() (time.Time, error) {
-	logp.Debug("beat", "getting registry info from %v", bt.config.RegistryFilePath)
-	reg, err := ioutil.ReadFile(bt.config.RegistryFilePath)
-	if err != nil {
-		logp.Warn("could not read registry file, may not exist (this is normal on first run). returning earliest possible time.")
-		return time.Time{}, nil
-	}
-	lastProcessed, err := time.Parse(time.RFC3339, string(reg))
-	if err != nil {
-		// handle corrupted state file the same way we handle missing state file
-		// (alternative: error out and let user try to fix state file)
-		logp.Warn("error parsing timestamp in registry file (%v): %v; returning earliest possible time.", bt.config.RegistryFilePath, string(reg))
-		return time.Time{}, nil
-	}
-	return lastProcessed, nil
-}
+	setMetricsTenants(bt.tenants)
+	defer setMetricsTenants(nil)
 
-func (bt *O365beat) putRegistry(lastProcessed time.Time) error {
-	logp.Debug("beat", "putting registry info (%v) to %v", lastProcessed, bt.config.RegistryFilePath)
-	ts := []byte(lastProcessed.Format(time.RFC3339))
-	err := ioutil.WriteFile(bt.config.RegistryFilePath, ts, 0644)
-	if err != nil {
-		logp.Error(err)
-		return err
-	}
+	// the webhook must be listening before subscriptions are started with it
+	if bt.config.Webhook.Enabled {
+		webhook := newWebhookServer(bt.config.Webhook, bt.tenants)
+		if err := webhook.start(); err != nil {
+			return err
+		}
+		defer webhook.stop()
+	}
+
+	// acknowledged progress is saved every registry.flush, and once more on shutdown
+	flushCtx, stopFlushing := context.WithCancel(context.Background())
+	go bt.registrar.run(flushCtx, bt.config.Registry.Flush)
+	defer func() {
+		stopFlushing()
+		bt.registrar.flush()
+	}()
+
+	var wg sync.WaitGroup
+	for _, t := range bt.tenants {
+		wg.Add(1)
+		go func(t *tenant) {
+			defer wg.Done()
+			t.run(ctx)
+		}(t)
+	}
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+
+	<-bt.done
+	closeClients = false
+	bt.shutdown(cancel, stopped)
 	return nil
 }
 
-// Run starts o365beat.
-func (bt *O365beat) Run(b *beat.Beat) error {
-	logp.Info("o365beat is running! Hit CTRL-C to stop it.")
-
-	var err error
-	bt.client, err = b.Publisher.Connect()
-	if err != nil {
-		logp.Error(err)
-		return err
-	}
-	ticker := time.NewTicker(bt.config.Period)
-
-	err = bt.enableSubscriptions()
-	if err != nil {
-		logp.Error(err)
-		return err
-	}
+// shutdownGrace is how long shutdown waits for polls to stop once the pipeline
+// clients are closed
+const shutdownGrace = 5 * time.Second
+
+// shutdown cancels the tenants' in-flight requests, then waits (up to
+// shutdown_timeout in all) for them to stop and for the events they published
+// to be acknowledged, so the registry records every fully published blob.
+// the pipeline clients are only closed after that, so nothing is published
+// into a closed client; polls get shutdownGrace more to stop once they are.
+func (bt *O365beat) shutdown(cancel context.CancelFunc, stopped <-chan struct{}) {
+	logp.Info("stopping o365beat, waiting up to %v for in-flight polls and acknowledgements", bt.config.ShutdownTimeout)
+	cancel()
+	deadline := time.After(bt.config.ShutdownTimeout)
+	defer func() {
+		for _, t := range bt.tenants {
+			t.client.Close()
+		}
+		// closing the clients unblocks any publishing, but a poll stuck elsewhere
+		// (e.g., in a request ignoring cancellation) mustn't hold up the exit
+		select {
+		case <-stopped:
+		case <-time.After(shutdownGrace):
+			logp.Warn("polls still running %v after closing the publisher, exiting anyway", shutdownGrace)
+		}
+	}()
 
-	// registry (state) is just the most recent "contentCreated" for processed blobs
-	// storing a timestamp means that blob and all before have been published.
-	lastProcessed, err := bt.getRegistry()
-	if err != nil {
-		logp.Error(err)
-		return err
+	select {
+	case <-stopped:
+	case <-deadline:
+		logp.Warn("polls didn't stop within shutdown_timeout (%v), closing the publisher", bt.config.ShutdownTimeout)
+		return
 	}
-
-	// ticker's first tick is AFTER its period, do "initial tick" in advance:
-	err = bt.poll(lastProcessed, b)
-	if err != nil {
-		logp.Error(err)
-		return err
-	}
-
-	// api polling loop:
-	for {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for !bt.registrar.drained() {
 		select {
-		case <-bt.done:
-			return nil
 		case <-ticker.C:
-		}
-		lastProcessed, err := bt.getRegistry()
-		if err != nil {
-			logp.Error(err)
-			return err
-		}
-		err = bt.poll(lastProcessed, b)
-		if err != nil {
-			logp.Error(err)
-			return err
+		case <-deadline:
+			logp.Warn("events still unacknowledged after shutdown_timeout (%v), they'll be collected again after restart", bt.config.ShutdownTimeout)
+			return
 		}
 	}
+	logp.Info("all published events acknowledged")
 }
 
-// Stop stops o365beat.
+// Stop stops o365beat (see shutdown).
 func (bt *O365beat) Stop() {
-	bt.client.Close()
 	close(bt.done)
 }
@@ -0,0 +1,134 @@
+// +build !integration
+
+package beater
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+
+	"github.com/counteractive/o365beat/config"
+	"github.com/counteractive/o365beat/o365api"
+)
+
+// testPipeline connects testClients, which never acknowledge anything
+type testPipeline struct {
+	mutex   sync.Mutex
+	clients []*testClient
+}
+
+func (p *testPipeline) Connect() (beat.Client, error) {
+	return p.ConnectWith(beat.ClientConfig{})
+}
+
+func (p *testPipeline) ConnectWith(beat.ClientConfig) (beat.Client, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	c := &testClient{}
+	p.clients = append(p.clients, c)
+	return c, nil
+}
+
+func (p *testPipeline) SetACKHandler(beat.PipelineACKHandler) error { return nil }
+
+// published returns the number of events published to the pipeline
+func (p *testPipeline) published() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	n := 0
+	for _, c := range p.clients {
+		c.mutex.Lock()
+		n += len(c.events)
+		c.mutex.Unlock()
+	}
+	return n
+}
+
+// testBeat returns a beat collecting Audit.General from s, keeping its files in dir
+func testBeat(dir string, s *contentServer) *O365beat {
+	c := config.DefaultConfig
+	c.RegistryFilePath = filepath.Join(dir, "o365beat.state")
+	c.DeadLetterPath = filepath.Join(dir, "o365beat.deadletters")
+	c.LoginURL, c.ResourceURL = s.URL, s.URL
+	return &O365beat{
+		done:   make(chan struct{}),
+		config: c,
+		tenantConfigs: []config.TenantConfig{{
+			Name:              "contoso",
+			TenantDomain:      "contoso.onmicrosoft.com",
+			ClientSecret:      "secret",
+			Auth:              config.AuthConfig{Mode: config.AuthModeSecret},
+			DirectoryID:       "d",
+			ContentTypes:      []string{"Audit.General"},
+			Period:            time.Hour,
+			RegistryNamespace: "t",
+		}},
+	}
+}
+
+func TestRunShutdownTimeout(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	s := newContentServer(nil, nil)
+	defer s.Close()
+	blob := s.blobs("a")[0]
+	blob.ContentCreated = time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	s.listed = []o365api.Content{blob}
+	bt := testBeat(dir, s)
+	bt.config.ShutdownTimeout = 200 * time.Millisecond
+
+	pipeline := &testPipeline{}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- bt.Run(&beat.Beat{Publisher: pipeline})
+	}()
+	for deadline := time.Now().Add(5 * time.Second); pipeline.published() < 2; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("the blob's events weren't published")
+		}
+	}
+
+	// the output never acknowledges the events, so Run gives up on them
+	stopped := time.Now()
+	bt.Stop()
+	select {
+	case err := <-errs:
+		if err != nil {
+			t.Fatal(err)
+		}
+		if waited := time.Since(stopped); waited < bt.config.ShutdownTimeout {
+			t.Errorf("returned after %v, without waiting for acknowledgements", waited)
+		}
+	case <-time.After(bt.config.ShutdownTimeout + time.Second):
+		t.Fatal("Run didn't return within shutdown_timeout")
+	}
+	if !pipeline.clients[0].closed {
+		t.Error("the pipeline client wasn't closed")
+	}
+	if c := bt.registrar.reg.cursor("t", "Audit.General"); c.processed(blob) {
+		t.Errorf("the unacknowledged blob was recorded: %+v", c)
+	}
+}
+
+func TestRunClosesClientsOnError(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	s := newContentServer(nil, nil)
+	defer s.Close()
+	bt := testBeat(dir, s)
+	other := bt.tenantConfigs[0]
+	other.Name, other.DirectoryID, other.RegistryNamespace = "fabrikam", "f", "f"
+	other.ClientSecret = "" // so its api client can't be created
+	bt.tenantConfigs = append(bt.tenantConfigs, other)
+
+	pipeline := &testPipeline{}
+	if err := bt.Run(&beat.Beat{Publisher: pipeline}); err == nil {
+		t.Fatal("no error for a tenant without a client secret")
+	}
+	if len(pipeline.clients) != 1 || !pipeline.clients[0].closed {
+		t.Errorf("the first tenant's pipeline client wasn't closed")
+	}
+}
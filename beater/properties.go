@@ -0,0 +1,194 @@
+package beater
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/common"
+
+	"github.com/counteractive/o365beat/config"
+)
+
+// default names decoded by properties.* when their lists are unset
+var (
+	defaultParameters = []string{
+		"Identity", "Name", "User", "AccessRights", "Enabled",
+		"ForwardTo", "ForwardAsAttachmentTo", "RedirectTo", "DeleteMessage",
+		"ForwardingAddress", "ForwardingSmtpAddress", "DeliverToMailboxAndForward",
+	}
+	defaultExtendedProperties = []string{
+		"UserAgent", "RequestType", "ResultStatusDetail", "UserAuthenticationMethod", "KeepMeSignedIn",
+	}
+	defaultModifiedProperties = []string{
+		"Role.DisplayName", "Role.WellKnownObjectName", "Group.DisplayName",
+		"AccountEnabled", "StrongAuthenticationMethod", "StrongAuthenticationRequirement",
+		"AssignedLicense", "UserPrincipalName", "AppAddress",
+	}
+)
+
+// propertyDecoder turns the API's arrays of name/value pairs (Parameters,
+// ExtendedProperties and ModifiedProperties) into objects keyed by name, e.g.
+// o365.parameters.ForwardTo, decoding only allowed names
+type propertyDecoder struct {
+	parameters         allowlist
+	extendedProperties allowlist
+	modifiedProperties allowlist
+}
+
+// allowlist is a set of names, nil if every name is allowed ("*")
+type allowlist map[string]bool
+
+func newAllowlist(names, defaults []string) allowlist {
+	if names == nil {
+		names = defaults
+	}
+	a := allowlist{}
+	for _, name := range names {
+		if name == "*" {
+			return nil
+		}
+		a[name] = true
+	}
+	return a
+}
+
+func (a allowlist) allows(name string) bool {
+	return a == nil || a[name]
+}
+
+// newPropertyDecoder returns the configured decoder, nil if it's disabled
+func newPropertyDecoder(c config.PropertiesConfig) *propertyDecoder {
+	if !c.Enabled {
+		return nil
+	}
+	return &propertyDecoder{
+		parameters:         newAllowlist(c.Parameters, defaultParameters),
+		extendedProperties: newAllowlist(c.ExtendedProperties, defaultExtendedProperties),
+		modifiedProperties: newAllowlist(c.ModifiedProperties, defaultModifiedProperties),
+	}
+}
+
+// decode adds the objects for evt's properties, leaving the raw fields as they are
+func (d *propertyDecoder) decode(evt common.MapStr) {
+	if values := decodeNameValues(evt["Parameters"], d.parameters); len(values) > 0 {
+		evt.Put("o365.parameters", values)
+	}
+	if values := decodeNameValues(evt["ExtendedProperties"], d.extendedProperties); len(values) > 0 {
+		evt.Put("o365.extended_properties", values)
+	}
+	if values := decodeModifiedProperties(evt["ModifiedProperties"], d.modifiedProperties); len(values) > 0 {
+		evt.Put("o365.modified_properties", values)
+	}
+}
+
+// decodeNameValues decodes [{"Name": "ForwardTo", "Value": "..."}, ...]
+func decodeNameValues(v interface{}, allowed allowlist) common.MapStr {
+	values := common.MapStr{}
+	for _, p := range propertyList(v, allowed) {
+		if value, ok := p["Value"]; ok {
+			values[propertyKey(p["Name"].(string))] = value
+		}
+	}
+	return values
+}
+
+// decodeModifiedProperties decodes [{"Name": "...", "OldValue": "...",
+// "NewValue": "..."}, ...] into old and new values, with the values added and
+// removed (azure ad's values are json arrays, exchange's are single values)
+func decodeModifiedProperties(v interface{}, allowed allowlist) common.MapStr {
+	values := common.MapStr{}
+	for _, p := range propertyList(v, allowed) {
+		change := common.MapStr{}
+		if oldValue, ok := p["OldValue"]; ok {
+			change["old"] = oldValue
+		}
+		if newValue, ok := p["NewValue"]; ok {
+			change["new"] = newValue
+		}
+		oldValues, newValues := propertyValues(p["OldValue"]), propertyValues(p["NewValue"])
+		if added := difference(newValues, oldValues); len(added) > 0 {
+			change["added"] = added
+		}
+		if removed := difference(oldValues, newValues); len(removed) > 0 {
+			change["removed"] = removed
+		}
+		values[propertyKey(p["Name"].(string))] = change
+	}
+	return values
+}
+
+// propertyList returns the allowed name/value pairs of v, skipping malformed ones
+func propertyList(v interface{}, allowed allowlist) []map[string]interface{} {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	var properties []map[string]interface{}
+	for _, item := range list {
+		p, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, ok := p["Name"].(string)
+		if !ok || name == "" || !allowed.allows(name) {
+			continue
+		}
+		properties = append(properties, p)
+	}
+	return properties
+}
+
+// propertyKey keeps names with dots (e.g., "Role.DisplayName") from being
+// expanded into nested objects
+func propertyKey(name string) string {
+	return strings.Replace(name, ".", "_", -1)
+}
+
+// propertyValues splits a modified property's value into its values: the
+// elements of a json array, or the value itself (none if it's empty)
+func propertyValues(v interface{}) []string {
+	s, ok := v.(string)
+	if !ok {
+		if v == nil {
+			return nil
+		}
+		return []string{fmt.Sprint(v)}
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	var elements []interface{}
+	if strings.HasPrefix(s, "[") && json.Unmarshal([]byte(s), &elements) == nil {
+		values := make([]string, 0, len(elements))
+		for _, e := range elements {
+			if str, ok := e.(string); ok {
+				values = append(values, str)
+				continue
+			}
+			data, _ := json.Marshal(e)
+			values = append(values, string(data))
+		}
+		return values
+	}
+	return []string{s}
+}
+
+// difference returns the values of a that aren't in b
+func difference(a, b []string) []string {
+	var diff []string
+	for _, v := range a {
+		found := false
+		for _, w := range b {
+			if v == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff = appendUnique(diff, v)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,119 @@
+// +build !integration
+
+package beater
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/elastic/beats/libbeat/common"
+
+	"github.com/counteractive/o365beat/config"
+)
+
+func nameValue(name string, values ...string) map[string]interface{} {
+	p := map[string]interface{}{"Name": name}
+	if len(values) > 0 {
+		p["OldValue"] = values[0]
+	}
+	if len(values) > 1 {
+		p["NewValue"] = values[1]
+	}
+	return p
+}
+
+func TestDecodeModifiedProperties(t *testing.T) {
+	tests := []struct {
+		name     string
+		property map[string]interface{}
+		want     common.MapStr
+	}{
+		{
+			"json arrays",
+			nameValue("AssignedLicense", `["E3","EMS"]`, `["E5","EMS"]`),
+			common.MapStr{"old": `["E3","EMS"]`, "new": `["E5","EMS"]`, "added": []string{"E5"}, "removed": []string{"E3"}},
+		},
+		{
+			"added only",
+			nameValue("Role.DisplayName", `[]`, `["Global Administrator"]`),
+			common.MapStr{"old": `[]`, "new": `["Global Administrator"]`, "added": []string{"Global Administrator"}},
+		},
+		{
+			"single values",
+			nameValue("AccountEnabled", "True", "False"),
+			common.MapStr{"old": "True", "new": "False", "added": []string{"False"}, "removed": []string{"True"}},
+		},
+		{
+			"unchanged",
+			nameValue("UserPrincipalName", "a@b.c", "a@b.c"),
+			common.MapStr{"old": "a@b.c", "new": "a@b.c"},
+		},
+		{
+			"empty old value",
+			nameValue("AppAddress", "", `[{"Address":"https://x"}]`),
+			common.MapStr{"old": "", "new": `[{"Address":"https://x"}]`, "added": []string{`{"Address":"https://x"}`}},
+		},
+	}
+	for _, test := range tests {
+		got := decodeModifiedProperties([]interface{}{test.property}, nil)
+		key := propertyKey(test.property["Name"].(string))
+		if !reflect.DeepEqual(got[key], test.want) || len(got) != 1 {
+			t.Errorf("%v: got %v, want %v", test.name, got, common.MapStr{key: test.want})
+		}
+	}
+}
+
+func TestAllowlist(t *testing.T) {
+	properties := []interface{}{
+		nameValue("Role.DisplayName", "a", "b"),
+		nameValue("Other", "a", "b"),
+		map[string]interface{}{"Value": "no name"},
+		"not an object",
+	}
+	tests := []struct {
+		names []string
+		want  []string
+	}{
+		{nil, []string{"Role_DisplayName"}}, // the defaults
+		{[]string{"Other"}, []string{"Other"}},
+		{[]string{"*"}, []string{"Role_DisplayName", "Other"}},
+		{[]string{}, nil},
+	}
+	for _, test := range tests {
+		got := decodeModifiedProperties(properties, newAllowlist(test.names, defaultModifiedProperties))
+		if len(got) != len(test.want) {
+			t.Errorf("%v: got %v, want %v", test.names, got, test.want)
+		}
+		for _, key := range test.want {
+			if _, ok := got[key]; !ok {
+				t.Errorf("%v: got %v, want %v", test.names, got, test.want)
+			}
+		}
+	}
+}
+
+func TestPropertyDecoder(t *testing.T) {
+	d := newPropertyDecoder(config.PropertiesConfig{Enabled: true})
+	evt := common.MapStr{
+		"Parameters": []interface{}{
+			map[string]interface{}{"Name": "ForwardTo", "Value": "x@example.com"},
+			map[string]interface{}{"Name": "Unlisted", "Value": "y"},
+		},
+		"ExtendedProperties": []interface{}{
+			map[string]interface{}{"Name": "UserAgent", "Value": "curl"},
+		},
+	}
+	d.decode(evt)
+	if got, _ := evt.GetValue("o365.parameters"); !reflect.DeepEqual(got, common.MapStr{"ForwardTo": "x@example.com"}) {
+		t.Errorf("parameters: got %v", got)
+	}
+	if got, _ := evt.GetValue("o365.extended_properties.UserAgent"); got != "curl" {
+		t.Errorf("extended properties: got %v", got)
+	}
+	if _, err := evt.GetValue("o365.modified_properties"); err == nil {
+		t.Error("added modified properties the event doesn't have")
+	}
+	if newPropertyDecoder(config.PropertiesConfig{}) != nil {
+		t.Error("disabled decoder isn't nil")
+	}
+}
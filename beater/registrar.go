@@ -0,0 +1,204 @@
+package beater
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+
+	"github.com/counteractive/o365beat/o365api"
+)
+
+// pendingBlob is a content blob whose events have been (or are being) published
+// but not yet acknowledged by the output.  it's attached to each of the blob's
+// events as beat.Event.Private, and comes back to the registrar in ackEvents.
+type pendingBlob struct {
+	tenantID string
+	blob     o365api.Content
+	events   int    // number of events published
+	acked    int    // number of events acknowledged
+	sealed   bool   // all events have been published (events is final)
+	pushed   bool   // delivered by webhook, so only marked processed (polls move the cursor)
+	onAcked  func() // optional, called (holding the registrar's mutex) once fully acknowledged
+}
+
+func (p *pendingBlob) done() bool {
+	return p.sealed && p.acked >= p.events
+}
+
+// queueKey identifies a tenant's content type
+type queueKey struct {
+	tenantID    string
+	contentType string
+}
+
+// registrar owns the registry and advances it as the output acknowledges
+// events.  blobs are queued per tenant and content type in publish order, and
+// a cursor only moves past a blob once it, and every blob queued before it,
+// is fully acknowledged, so events lost in the pipeline (e.g., on a crash
+// while the output is down) are re-read on restart.  changes are persisted by
+// flush (see run), outside the mutex, so slow backends don't hold up acks.
+type registrar struct {
+	mutex    sync.Mutex
+	reg      *registry
+	overlap  time.Duration
+	queues   map[queueKey][]*pendingBlob
+	inFlight map[string]bool // content ids queued but not yet fully acknowledged
+	dirty    bool            // the registry changed since it was last persisted
+
+	persist    func(data []byte) error // saves the encoded registry
+	flushMutex sync.Mutex              // serializes flushes, so an older registry can't overwrite a newer one
+}
+
+func newRegistrar(reg *registry, overlap time.Duration, persist func(data []byte) error) *registrar {
+	return &registrar{
+		reg:      reg,
+		overlap:  overlap,
+		persist:  persist,
+		queues:   map[queueKey][]*pendingBlob{},
+		inFlight: map[string]bool{},
+	}
+}
+
+// start returns the time to start listing a content type (zero if no cursor)
+func (r *registrar) start(tenantID, contentType string) time.Time {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	c := r.reg.cursor(tenantID, contentType)
+	if c.ContentCreated.IsZero() {
+		return time.Time{}
+	}
+	return c.start(r.overlap)
+}
+
+// skip reports whether the blob was already processed or is still in flight
+func (r *registrar) skip(tenantID string, blob o365api.Content) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.inFlight[blob.ContentID] || r.reg.cursor(tenantID, blob.ContentType).processed(blob)
+}
+
+// add queues a blob before its events are published
+func (r *registrar) add(tenantID string, blob o365api.Content, pushed bool) *pendingBlob {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	p := &pendingBlob{tenantID: tenantID, blob: blob, pushed: pushed}
+	key := queueKey{tenantID, blob.ContentType}
+	r.queues[key] = append(r.queues[key], p)
+	r.inFlight[blob.ContentID] = true
+	return p
+}
+
+// published records that all of a blob's events (count) have been published
+func (r *registrar) published(p *pendingBlob, count int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	p.events = count
+	p.sealed = true
+	r.advance()
+}
+
+// pass queues an already processed blob (with no events) that's newer than
+// the cursor, so polls can move the cursor past blobs delivered by webhook
+func (r *registrar) pass(tenantID string, blob o365api.Content) {
+	r.mutex.Lock()
+	c := r.reg.cursor(tenantID, blob.ContentType)
+	stale := r.inFlight[blob.ContentID] || !c.processed(blob) || !blob.ContentCreated.After(c.ContentCreated)
+	r.mutex.Unlock()
+	if stale {
+		return
+	}
+	r.published(r.add(tenantID, blob, false), 0)
+}
+
+// drained reports whether every queued blob has been fully acknowledged
+func (r *registrar) drained() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.queues) == 0
+}
+
+// ackEvents is the pipeline's ACKEvents callback, receiving the Private
+// field of each acknowledged event (in publish order)
+func (r *registrar) ackEvents(privates []interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, private := range privates {
+		if p, ok := private.(*pendingBlob); ok {
+			p.acked++
+		}
+	}
+	r.advance()
+}
+
+// advance moves cursors past fully acknowledged blobs at the front of each
+// queue, marking the registry dirty if anything changed (the caller must hold
+// r.mutex)
+func (r *registrar) advance() {
+	for key, queue := range r.queues {
+		for len(queue) > 0 && queue[0].done() {
+			p := queue[0]
+			logp.Debug("beat", "all %v event(s) acknowledged for %v blob created %v, updating registry", p.events, p.blob.ContentType, p.blob.ContentCreated)
+			if p.pushed {
+				r.reg.markProcessed(p.tenantID, p.blob, r.overlap)
+			} else {
+				r.reg.advance(p.tenantID, p.blob, r.overlap)
+			}
+			delete(r.inFlight, p.blob.ContentID)
+			if p.onAcked != nil {
+				p.onAcked()
+			}
+			queue = queue[1:]
+			r.dirty = true
+		}
+		if len(queue) == 0 {
+			delete(r.queues, key)
+		} else {
+			r.queues[key] = queue
+		}
+	}
+}
+
+// run flushes the registry every interval until ctx is done (callers flush
+// once more after that, once acknowledgements have stopped)
+func (r *registrar) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.flush()
+		}
+	}
+}
+
+// flush persists the registry if it changed since the last flush.  it's
+// encoded under the mutex but saved outside it; if saving fails, it's retried
+// on the next flush.
+func (r *registrar) flush() error {
+	r.flushMutex.Lock()
+	defer r.flushMutex.Unlock()
+
+	r.mutex.Lock()
+	if !r.dirty {
+		r.mutex.Unlock()
+		return nil
+	}
+	data, err := json.MarshalIndent(r.reg, "", "  ")
+	r.dirty = false
+	r.mutex.Unlock()
+	if err == nil {
+		err = r.persist(data)
+	}
+	if err != nil {
+		logp.Err("error persisting registry: %v", err)
+		r.mutex.Lock()
+		r.dirty = true
+		r.mutex.Unlock()
+	}
+	return err
+}
@@ -0,0 +1,165 @@
+// +build !integration
+
+package beater
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/counteractive/o365beat/o365api"
+)
+
+var testEpoch = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func testBlob(id string, created time.Duration) o365api.Content {
+	return o365api.Content{ContentType: "Audit.General", ContentID: id, ContentCreated: testEpoch.Add(created)}
+}
+
+// testRegistrar returns a registrar whose flushes are appended to saved
+func testRegistrar() (*registrar, *[]*registry) {
+	var saved []*registry
+	r := newRegistrar(newRegistry(), time.Hour, func(data []byte) error {
+		reg := newRegistry()
+		if err := json.Unmarshal(data, reg); err != nil {
+			return err
+		}
+		saved = append(saved, reg)
+		return nil
+	})
+	return r, &saved
+}
+
+func TestRegistrarOutOfOrderAcks(t *testing.T) {
+	r, _ := testRegistrar()
+	a := r.add("t", testBlob("a", 0), false)
+	b := r.add("t", testBlob("b", time.Second), false)
+	r.published(a, 2)
+	r.published(b, 1)
+
+	// b is acknowledged first, but the cursor waits for a
+	r.ackEvents([]interface{}{b})
+	if c := r.reg.cursor("t", "Audit.General"); !c.ContentCreated.IsZero() {
+		t.Fatalf("cursor moved past an unacknowledged blob: %+v", c)
+	}
+	if !r.skip("t", b.blob) {
+		t.Fatal("an acknowledged but queued blob would be published again")
+	}
+	r.ackEvents([]interface{}{a})
+	if c := r.reg.cursor("t", "Audit.General"); !c.ContentCreated.IsZero() {
+		t.Fatalf("cursor moved past a partly acknowledged blob: %+v", c)
+	}
+	r.ackEvents([]interface{}{a})
+	if c := r.reg.cursor("t", "Audit.General"); c.ContentID != "b" || len(c.Processed) != 2 {
+		t.Fatalf("got %+v, want the cursor at b", c)
+	}
+	if !r.drained() {
+		t.Fatal("not drained once everything was acknowledged")
+	}
+}
+
+func TestRegistrarQueuesAreIndependent(t *testing.T) {
+	r, _ := testRegistrar()
+	a := r.add("t", testBlob("a", 0), false)
+	other := r.add("other", testBlob("b", time.Second), false)
+	r.published(a, 1)
+	r.published(other, 1)
+	r.ackEvents([]interface{}{other})
+	if c := r.reg.cursor("other", "Audit.General"); c.ContentID != "b" {
+		t.Fatalf("another tenant's unacknowledged blob held the cursor: %+v", c)
+	}
+	if r.drained() {
+		t.Fatal("drained with a blob unacknowledged")
+	}
+}
+
+func TestRegistrarZeroEventBlobs(t *testing.T) {
+	r, _ := testRegistrar()
+	a := r.add("t", testBlob("a", 0), false)
+	if r.drained() {
+		t.Fatal("drained while a blob is being published")
+	}
+	r.published(a, 0)
+	if c := r.reg.cursor("t", "Audit.General"); c.ContentID != "a" {
+		t.Fatalf("got %+v, want the cursor at the empty blob", c)
+	}
+	if !r.drained() {
+		t.Fatal("not drained after an empty blob")
+	}
+}
+
+func TestRegistrarPass(t *testing.T) {
+	r, _ := testRegistrar()
+
+	// a webhook delivers b, which is marked processed without moving the cursor
+	b := r.add("t", testBlob("b", 2*time.Second), true)
+	r.published(b, 1)
+	r.ackEvents([]interface{}{b})
+	if c := r.reg.cursor("t", "Audit.General"); !c.ContentCreated.IsZero() || !c.processed(b.blob) {
+		t.Fatalf("got %+v, want b processed and the cursor unmoved", c)
+	}
+
+	// a poll then lists the older a (new) and b (processed, passed)
+	a := r.add("t", testBlob("a", time.Second), false)
+	r.published(a, 1)
+	r.pass("t", b.blob)
+	if c := r.reg.cursor("t", "Audit.General"); !c.ContentCreated.IsZero() {
+		t.Fatalf("cursor moved past an unacknowledged blob: %+v", c)
+	}
+	r.ackEvents([]interface{}{a})
+	if c := r.reg.cursor("t", "Audit.General"); c.ContentID != "b" {
+		t.Fatalf("got %+v, want the cursor moved past the passed blob", c)
+	}
+
+	// blobs that aren't processed, or are behind the cursor, aren't passed
+	r.pass("t", testBlob("c", 3*time.Second))
+	r.pass("t", testBlob("a", time.Second))
+	if !r.drained() {
+		t.Fatal("passed a blob that's unprocessed or behind the cursor")
+	}
+}
+
+func TestRegistrarFlush(t *testing.T) {
+	r, saved := testRegistrar()
+	if err := r.flush(); err != nil || len(*saved) != 0 {
+		t.Fatalf("flushed an unchanged registry: %v, %v", err, len(*saved))
+	}
+	a := r.add("t", testBlob("a", 0), false)
+	r.published(a, 0)
+	b := r.add("t", testBlob("b", time.Second), false)
+	r.published(b, 0)
+	if len(*saved) != 0 {
+		t.Fatal("persisted before a flush")
+	}
+	if err := r.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if len(*saved) != 1 || (*saved)[0].cursor("t", "Audit.General").ContentID != "b" {
+		t.Fatalf("got %v flush(es), want one with the cursor at b", len(*saved))
+	}
+
+	// a failed save is retried on the next flush
+	fail := true
+	r.persist = func(data []byte) error {
+		if fail {
+			return errors.New("unavailable")
+		}
+		return nil
+	}
+	c := r.add("t", testBlob("c", 2*time.Second), false)
+	r.published(c, 0)
+	if err := r.flush(); err == nil {
+		t.Fatal("expected the save to fail")
+	}
+	fail = false
+	if err := r.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if r.dirty {
+		t.Fatal("still dirty after a successful flush")
+	}
+}
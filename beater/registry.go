@@ -0,0 +1,194 @@
+package beater
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+
+	"github.com/counteractive/o365beat/config"
+	"github.com/counteractive/o365beat/o365api"
+)
+
+// registryVersion is the current registry file format.  version 1 (implied)
+// was a bare RFC3339 timestamp shared by all content types.
+const registryVersion = 2
+
+// registry (state) tracks the most recent processed content blob for each
+// tenant and content type.  storing a cursor means that blob and all before
+// it (of that content type, for that tenant) have been published.
+type registry struct {
+	Version int                      `json:"version"`
+	Tenants map[string]tenantCursors `json:"tenants"` // keyed by tenant registry namespace (directory id by default)
+}
+
+// tenantCursors holds a tenant's cursors, keyed by content type
+type tenantCursors map[string]cursor
+
+// cursor identifies the last processed content blob of a content type, along
+// with the ids of blobs processed within the overlap window before it (polls
+// re-list that window so blobs sharing a contentCreated second, or listed late,
+// aren't skipped, and use the processed ids so they aren't published twice)
+type cursor struct {
+	ContentCreated time.Time            `json:"contentCreated"`
+	ContentID      string               `json:"contentId,omitempty"`
+	Processed      map[string]time.Time `json:"processed,omitempty"` // contentId -> contentCreated
+}
+
+// start returns the time to start listing content after this cursor
+// (cursors from older registries don't track processed ids, so those
+// start just after the cursor, as before)
+func (c cursor) start(overlap time.Duration) time.Time {
+	if c.Processed == nil {
+		return c.ContentCreated.Add(time.Second) // API granularity is by the second
+	}
+	return c.ContentCreated.Add(-overlap)
+}
+
+// processed reports whether the blob was already processed
+func (c cursor) processed(blob o365api.Content) bool {
+	_, ok := c.Processed[blob.ContentID]
+	return ok
+}
+
+func newRegistry() *registry {
+	return &registry{Version: registryVersion, Tenants: map[string]tenantCursors{}}
+}
+
+// cursor returns the cursor for the tenant and content type (zero if none)
+func (r *registry) cursor(tenantID, contentType string) cursor {
+	return r.Tenants[tenantID][contentType]
+}
+
+// advance records the blob as processed, moves the tenant's cursor for its
+// content type forward to it (if it's newer), and prunes processed ids that
+// have fallen out of the overlap window
+func (r *registry) advance(tenantID string, blob o365api.Content, overlap time.Duration) {
+	r.record(tenantID, blob, overlap, true)
+}
+
+// markProcessed records the blob as processed without moving the cursor (for
+// blobs delivered by webhook, which may arrive ahead of older content)
+func (r *registry) markProcessed(tenantID string, blob o365api.Content, overlap time.Duration) {
+	r.record(tenantID, blob, overlap, false)
+}
+
+func (r *registry) record(tenantID string, blob o365api.Content, overlap time.Duration, move bool) {
+	cursors, ok := r.Tenants[tenantID]
+	if !ok {
+		cursors = tenantCursors{}
+		r.Tenants[tenantID] = cursors
+	}
+	c := cursors[blob.ContentType]
+	if c.Processed == nil {
+		c.Processed = map[string]time.Time{}
+	}
+	c.Processed[blob.ContentID] = blob.ContentCreated
+	if move && !blob.ContentCreated.Before(c.ContentCreated) {
+		c.ContentCreated = blob.ContentCreated
+		c.ContentID = blob.ContentID
+	}
+	oldest := c.ContentCreated.Add(-overlap)
+	for id, created := range c.Processed {
+		if created.Before(oldest) {
+			delete(c.Processed, id)
+		}
+	}
+	cursors[blob.ContentType] = c
+}
+
+// migrateRegistry converts a version 1 registry (a single timestamp) to the
+// current format, applying the timestamp to each of the tenant's content types
+func migrateRegistry(lastProcessed time.Time, t config.TenantConfig) *registry {
+	reg := newRegistry()
+	cursors := tenantCursors{}
+	for _, contentType := range t.ContentTypes {
+		cursors[contentType] = cursor{ContentCreated: lastProcessed}
+	}
+	reg.Tenants[t.RegistryNamespace] = cursors
+	return reg
+}
+
+// getRegistry loads the registry from its backend.  if it's corrupted, the
+// registry_on_corruption policy decides what to do.
+func (bt *O365beat) getRegistry() (*registry, error) {
+	logp.Debug("beat", "getting registry info from %v", bt.store)
+	data, err := bt.store.Load()
+	if err != nil {
+		logp.Error(err)
+		return nil, err
+	}
+	if data == nil {
+		logp.Warn("could not read registry file, may not exist (this is normal on first run). starting from earliest possible time.")
+		return newRegistry(), nil
+	}
+
+	reg, err := bt.decodeRegistry(data)
+	if err == nil {
+		return reg, nil
+	}
+	err = fmt.Errorf("error parsing registry (%v): %v", bt.store, err)
+	switch bt.config.RegistryOnCorruption {
+	case config.RegistryOnCorruptionRewind:
+		logp.Warn("%v; starting from earliest possible time.", err)
+		return newRegistry(), nil
+	case config.RegistryOnCorruptionBackup:
+		if backup, bakErr := bt.store.LoadBackup(); bakErr == nil && backup != nil {
+			if reg, bakErr := bt.decodeRegistry(backup); bakErr == nil {
+				logp.Warn("%v; using its backup instead.", err)
+				return reg, nil
+			}
+		}
+		err = fmt.Errorf("%v, and it has no usable backup (set registry_on_corruption: rewind to start from earliest possible time)", err)
+	}
+	logp.Error(err)
+	return nil, err
+}
+
+// decodeRegistry parses a registry in the current format, or migrates one in the
+// version 1 format (a single timestamp for all content types)
+func (bt *O365beat) decodeRegistry(data []byte) (*registry, error) {
+	reg := newRegistry()
+	if err := json.Unmarshal(data, reg); err == nil && reg.Version > 0 {
+		if reg.Tenants == nil {
+			reg.Tenants = map[string]tenantCursors{}
+		}
+		return reg, nil
+	}
+
+	lastProcessed, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("registry is neither json nor an RFC3339 timestamp")
+	}
+	// the old registry belonged to the top-level tenant (or the only one listed)
+	for _, t := range bt.tenantConfigs {
+		if t.DirectoryID == bt.config.DirectoryID || len(bt.tenantConfigs) == 1 {
+			logp.Info("migrating registry (%v) from single timestamp (%v) to per-content-type cursors for %v", bt.store, lastProcessed, t.Name)
+			return migrateRegistry(lastProcessed, t), nil
+		}
+	}
+	logp.Warn("registry (%v) has a single timestamp (%v) but no matching tenant; starting from earliest possible time.", bt.store, lastProcessed)
+	return newRegistry(), nil
+}
+
+func (bt *O365beat) putRegistry(reg *registry) error {
+	data, err := json.MarshalIndent(reg, "", "  ")
+	if err != nil {
+		logp.Error(err)
+		return err
+	}
+	return bt.saveRegistry(data)
+}
+
+// saveRegistry stores an encoded registry (the registrar's persist func)
+func (bt *O365beat) saveRegistry(data []byte) error {
+	logp.Debug("beat", "putting registry info to %v", bt.store)
+	err := bt.store.Save(data)
+	if err != nil {
+		logp.Error(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package beater
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+
+	"github.com/counteractive/o365beat/config"
+)
+
+// RegistryEditor reads and writes the registry for the registry commands, with
+// the same config and code as the beat
+type RegistryEditor struct {
+	bt   *O365beat
+	lock *registryLock // nil unless writable
+}
+
+// CursorInfo describes a registry cursor
+type CursorInfo struct {
+	Tenant         string    `json:"tenant"` // configured tenant name, empty if none uses the namespace
+	Namespace      string    `json:"namespace"`
+	ContentType    string    `json:"contentType"`
+	ContentCreated time.Time `json:"contentCreated"`
+	ContentID      string    `json:"contentId"`
+	Processed      int       `json:"processed"` // ids remembered in the overlap window
+}
+
+// OpenRegistry unpacks the beat's config and opens its registry.  writable
+// editors hold the registry lock until Close, so they can't be opened while a
+// beat is running (and a beat can't start meanwhile).
+func OpenRegistry(cfg *common.Config, writable bool) (*RegistryEditor, error) {
+	bt, err := newO365beat(cfg)
+	if err != nil {
+		return nil, err
+	}
+	e := &RegistryEditor{bt: bt}
+	if writable {
+		if e.lock, err = lockRegistry(bt.config.RegistryFilePath); err != nil {
+			return nil, err
+		}
+	}
+	if bt.store, err = openRegistryStore(bt.config); err != nil {
+		e.Close()
+		return nil, err
+	}
+	if writable {
+		if err := bt.store.Lock(); err != nil {
+			e.Close()
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Close closes the registry and releases its lock, if held
+func (e *RegistryEditor) Close() {
+	if e.bt.store != nil {
+		e.bt.store.Close()
+	}
+	if e.lock != nil {
+		e.lock.unlock()
+	}
+}
+
+// Cursors lists the registry's cursors, sorted by namespace and content type
+func (e *RegistryEditor) Cursors() ([]CursorInfo, error) {
+	reg, err := e.bt.getRegistry()
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]string{}
+	for _, t := range e.bt.tenantConfigs {
+		names[t.RegistryNamespace] = t.Name
+	}
+	var cursors []CursorInfo
+	for namespace, tc := range reg.Tenants {
+		for contentType, c := range tc {
+			cursors = append(cursors, CursorInfo{
+				Tenant:         names[namespace],
+				Namespace:      namespace,
+				ContentType:    contentType,
+				ContentCreated: c.ContentCreated,
+				ContentID:      c.ContentID,
+				Processed:      len(c.Processed),
+			})
+		}
+	}
+	sort.Slice(cursors, func(i, j int) bool {
+		if cursors[i].Namespace != cursors[j].Namespace {
+			return cursors[i].Namespace < cursors[j].Namespace
+		}
+		return cursors[i].ContentType < cursors[j].ContentType
+	})
+	return cursors, nil
+}
+
+// Export writes the registry as json (the registry file format)
+func (e *RegistryEditor) Export(w io.Writer) error {
+	reg, err := e.bt.getRegistry()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(reg, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// Import replaces the registry with one read from r (in either registry format).
+// exported registries are imported as they are: cursors without a time (e.g.,
+// only webhook content processed) and cursors older than content_max_age (quiet
+// content types, which the beat starts at content_max_age ago) are normal, so
+// only cursors in the future are rejected.
+func (e *RegistryEditor) Import(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	reg, err := e.bt.decodeRegistry(data)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	oldest := now.Add(-e.bt.config.ContentMaxAge)
+	for namespace, tc := range reg.Tenants {
+		for contentType, c := range tc {
+			switch {
+			case c.ContentCreated.After(now):
+				return fmt.Errorf("%v %v cursor: time %v is in the future", namespace, contentType, c.ContentCreated)
+			case !c.ContentCreated.IsZero() && c.ContentCreated.Before(oldest):
+				logp.Warn("%v %v cursor (%v) is older than content_max_age (%v), so collection will start at %v", namespace, contentType, c.ContentCreated, e.bt.config.ContentMaxAge, oldest)
+			}
+		}
+	}
+	return e.bt.putRegistry(reg)
+}
+
+// Set moves the named tenants' cursors (all configured tenants if none) for
+// the content types (each tenant's configured types if none) to at, so
+// collection resumes with content created after it
+func (e *RegistryEditor) Set(tenants, contentTypes []string, at time.Time) error {
+	if err := e.checkTime(at, time.Now()); err != nil {
+		return err
+	}
+	selected, err := selectTenants(e.bt.tenantConfigs, tenants)
+	if err != nil {
+		return err
+	}
+	reg, err := e.bt.getRegistry()
+	if err != nil {
+		return err
+	}
+	for _, t := range selected {
+		cursors, ok := reg.Tenants[t.RegistryNamespace]
+		if !ok {
+			cursors = tenantCursors{}
+			reg.Tenants[t.RegistryNamespace] = cursors
+		}
+		for _, contentType := range contentTypesFor(t, contentTypes) {
+			// without processed ids, polls start just after the cursor
+			cursors[contentType] = cursor{ContentCreated: at}
+		}
+	}
+	return e.bt.putRegistry(reg)
+}
+
+// Reset removes the named tenants' cursors (all cursors if no tenants or content
+// types are named) for the content types (all of the tenant's if none), so
+// collection starts over from content_max_age ago
+func (e *RegistryEditor) Reset(tenants, contentTypes []string) error {
+	if len(tenants) == 0 && len(contentTypes) == 0 {
+		return e.bt.putRegistry(newRegistry())
+	}
+	selected, err := selectTenants(e.bt.tenantConfigs, tenants)
+	if err != nil {
+		return err
+	}
+	reg, err := e.bt.getRegistry()
+	if err != nil {
+		return err
+	}
+	for _, t := range selected {
+		if len(contentTypes) == 0 {
+			delete(reg.Tenants, t.RegistryNamespace)
+			continue
+		}
+		for _, contentType := range contentTypes {
+			delete(reg.Tenants[t.RegistryNamespace], contentType)
+		}
+	}
+	return e.bt.putRegistry(reg)
+}
+
+// checkTime rejects cursor times (for Set) in the future or older than
+// content_max_age (which the beat would ignore anyway)
+func (e *RegistryEditor) checkTime(t, now time.Time) error {
+	if t.After(now) {
+		return fmt.Errorf("time %v is in the future", t)
+	}
+	if oldest := now.Add(-e.bt.config.ContentMaxAge); t.Before(oldest) {
+		return fmt.Errorf("time %v is older than content_max_age (%v), so it would be ignored", t, e.bt.config.ContentMaxAge)
+	}
+	return nil
+}
+
+func contentTypesFor(t config.TenantConfig, contentTypes []string) []string {
+	if len(contentTypes) > 0 {
+		return contentTypes
+	}
+	return t.ContentTypes
+}
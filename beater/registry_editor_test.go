@@ -0,0 +1,116 @@
+// +build !integration
+
+package beater
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/counteractive/o365beat/config"
+)
+
+// testEditor returns an editor for a file registry in dir, with one tenant
+func testEditor(dir string) *RegistryEditor {
+	c := config.DefaultConfig
+	c.RegistryFilePath = filepath.Join(dir, "o365beat.state")
+	return &RegistryEditor{bt: &O365beat{
+		config: c,
+		tenantConfigs: []config.TenantConfig{{
+			Name:              "contoso",
+			RegistryNamespace: "ns",
+			ContentTypes:      []string{"Audit.General", "Audit.Exchange"},
+		}},
+		store: &fileRegistry{path: c.RegistryFilePath},
+	}}
+}
+
+func TestRegistryExportImport(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	e := testEditor(dir)
+
+	// a live registry: a recent cursor, a quiet content type's stale cursor,
+	// and a cursor with only webhook content processed
+	now := time.Now().UTC().Truncate(time.Second)
+	reg := newRegistry()
+	reg.Tenants["ns"] = tenantCursors{
+		"Audit.General": cursor{
+			ContentCreated: now.Add(-time.Hour),
+			ContentID:      "a",
+			Processed:      map[string]time.Time{"a": now.Add(-time.Hour)},
+		},
+		"Audit.Exchange": cursor{ContentCreated: now.Add(-30 * 24 * time.Hour), ContentID: "b"},
+		"DLP.All":        cursor{Processed: map[string]time.Time{"c": now}},
+	}
+	if err := e.bt.putRegistry(reg); err != nil {
+		t.Fatal(err)
+	}
+
+	var exported bytes.Buffer
+	if err := e.Export(&exported); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Reset(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Import(bytes.NewReader(exported.Bytes())); err != nil {
+		t.Fatalf("importing an exported registry: %v", err)
+	}
+	imported, err := e.bt.getRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(imported, reg) {
+		t.Errorf("imported %+v, want %+v", imported, reg)
+	}
+
+	future := `{"version":2,"tenants":{"ns":{"Audit.General":{"contentCreated":"` + now.Add(time.Hour).Format(time.RFC3339) + `"}}}}`
+	if err := e.Import(bytes.NewBufferString(future)); err == nil {
+		t.Error("imported a cursor in the future")
+	}
+	if unchanged, _ := e.bt.getRegistry(); !reflect.DeepEqual(unchanged, reg) {
+		t.Errorf("rejected import changed the registry to %+v", unchanged)
+	}
+}
+
+func TestRegistrySet(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	e := testEditor(dir)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, at := range []time.Time{{}, now.Add(-30 * 24 * time.Hour), now.Add(time.Hour)} {
+		if err := e.Set(nil, nil, at); err == nil {
+			t.Errorf("set cursors to %v", at)
+		}
+	}
+
+	at := now.Add(-time.Hour)
+	if err := e.Set(nil, []string{"Audit.General"}, at); err != nil {
+		t.Fatal(err)
+	}
+	cursors, err := e.Cursors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CursorInfo{{Tenant: "contoso", Namespace: "ns", ContentType: "Audit.General", ContentCreated: at}}
+	if !reflect.DeepEqual(cursors, want) {
+		t.Errorf("got %+v, want %+v", cursors, want)
+	}
+
+	if err := e.Set([]string{"fabrikam"}, nil, at); err == nil {
+		t.Error("set cursors for an unconfigured tenant")
+	}
+	if err := e.Set(nil, nil, at); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Reset(nil, []string{"Audit.Exchange"}); err != nil {
+		t.Fatal(err)
+	}
+	if cursors, _ = e.Cursors(); len(cursors) != 1 || cursors[0].ContentType != "Audit.General" {
+		t.Errorf("after resetting Audit.Exchange: got %+v", cursors)
+	}
+}
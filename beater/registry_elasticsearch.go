@@ -0,0 +1,341 @@
+package beater
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+
+	"github.com/counteractive/o365beat/config"
+)
+
+// elasticsearchRegistry stores the registry in an elasticsearch document, for
+// deployments without persistent local storage (e.g., containers).  the last
+// document read or written is kept, so saves don't read it back for the
+// backup, and saves are conditional on its sequence number, so they fail
+// rather than overwrite a registry another beat has written meanwhile.
+type elasticsearchRegistry struct {
+	config     config.ElasticsearchRegistryConfig
+	httpClient *http.Client
+	indexReady bool // the index exists (with its mapping)
+
+	current     *elasticsearchRegistryDoc // nil until read (or if there's none)
+	read        bool                      // the document has been read (current is up to date)
+	seqNo       int64                     // current's _seq_no and _primary_term
+	primaryTerm int64
+
+	lock     *elasticsearchLockDoc // held lock, nil if none
+	lockStop chan struct{}         // stops renewing the lock
+	lockDone chan struct{}         // closed once renewing has stopped
+}
+
+// elasticsearchLockDoc is the registry's lock document (<document_id>.lock),
+// created by the writer and renewed until it's done.  a lock that's expired
+// (e.g., its beat crashed) is taken over.
+type elasticsearchLockDoc struct {
+	Owner       string    `json:"owner"` // host and pid
+	Acquired    time.Time `json:"acquired"`
+	Expires     time.Time `json:"expires"`
+	seqNo       int64
+	primaryTerm int64
+}
+
+// elasticsearchLockLease is how long a lock lasts unless it's renewed (it's
+// renewed every third of that)
+const elasticsearchLockLease = time.Minute
+
+// elasticsearchRegistryDoc is the registry's document.  the registries are kept
+// as strings in an unmapped index, so their content ids don't become fields.
+type elasticsearchRegistryDoc struct {
+	Registry string    `json:"registry"`
+	Backup   string    `json:"backup,omitempty"`
+	Updated  time.Time `json:"updated"`
+}
+
+// elasticsearchRegistryMapping only maps the update time
+const elasticsearchRegistryMapping = `{"mappings":{"dynamic":false,"properties":{"updated":{"type":"date"}}}}`
+
+func newElasticsearchRegistry(c config.ElasticsearchRegistryConfig) *elasticsearchRegistry {
+	return &elasticsearchRegistry{config: c, httpClient: &http.Client{Timeout: c.Timeout}}
+}
+
+func (r *elasticsearchRegistry) Load() ([]byte, error) {
+	if err := r.get(); err != nil || r.current == nil {
+		return nil, err
+	}
+	return []byte(r.current.Registry), nil
+}
+
+func (r *elasticsearchRegistry) LoadBackup() ([]byte, error) {
+	if err := r.get(); err != nil || r.current == nil || r.current.Backup == "" {
+		return nil, err
+	}
+	return []byte(r.current.Backup), nil
+}
+
+// get reads the registry document (current is nil if there's none)
+func (r *elasticsearchRegistry) get() error {
+	status, body, err := r.request("GET", r.docPath(), nil)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		r.current, r.read = nil, true
+		return nil
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("error getting registry from %v: %v %s", r, status, body)
+	}
+	var res struct {
+		SeqNo       int64                    `json:"_seq_no"`
+		PrimaryTerm int64                    `json:"_primary_term"`
+		Source      elasticsearchRegistryDoc `json:"_source"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return fmt.Errorf("error decoding registry document from %v: %v", r, err)
+	}
+	r.current, r.read = &res.Source, true
+	r.seqNo, r.primaryTerm = res.SeqNo, res.PrimaryTerm
+	return nil
+}
+
+// Save replaces the registry document, keeping the registry it replaces as the
+// backup.  it fails if the document was changed since it was last read or
+// written (e.g., by another beat using the same document_id).
+func (r *elasticsearchRegistry) Save(data []byte) error {
+	if err := r.ensureIndex(); err != nil {
+		return err
+	}
+	if !r.read {
+		if err := r.get(); err != nil {
+			return err
+		}
+	}
+	doc := elasticsearchRegistryDoc{Registry: string(data), Updated: time.Now().UTC()}
+	path := r.docPath() + "?op_type=create"
+	if r.current != nil {
+		doc.Backup = r.current.Registry
+		path = fmt.Sprintf("%v?if_seq_no=%v&if_primary_term=%v", r.docPath(), r.seqNo, r.primaryTerm)
+	}
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	status, res, err := r.request("PUT", path, body)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusConflict {
+		r.read = false // read it again before the next save
+		return fmt.Errorf("registry in %v was changed by something else (is another o365beat using its document_id?): %s", r, res)
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		return fmt.Errorf("error saving registry to %v: %v %s", r, status, res)
+	}
+	var saved struct {
+		SeqNo       int64 `json:"_seq_no"`
+		PrimaryTerm int64 `json:"_primary_term"`
+	}
+	if err := json.Unmarshal(res, &saved); err != nil {
+		r.read = false
+		return fmt.Errorf("error decoding registry save response from %v: %v", r, err)
+	}
+	r.current = &doc
+	r.seqNo, r.primaryTerm = saved.SeqNo, saved.PrimaryTerm
+	return nil
+}
+
+// ensureIndex creates the registry index (unless it exists) before the first save
+func (r *elasticsearchRegistry) ensureIndex() error {
+	if r.indexReady {
+		return nil
+	}
+	status, res, err := r.request("PUT", "/"+r.config.Index, []byte(elasticsearchRegistryMapping))
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && !bytes.Contains(res, []byte("resource_already_exists_exception")) {
+		return fmt.Errorf("error creating registry index %v: %v %s", r.config.Index, status, res)
+	}
+	r.indexReady = true
+	return nil
+}
+
+func (r *elasticsearchRegistry) docPath() string {
+	return "/" + r.config.Index + "/_doc/" + r.config.DocumentID
+}
+
+func (r *elasticsearchRegistry) lockPath() string {
+	return r.docPath() + ".lock"
+}
+
+// Lock creates the lock document, or takes it over if it's expired, and keeps
+// renewing it until Close
+func (r *elasticsearchRegistry) Lock() error {
+	if err := r.ensureIndex(); err != nil {
+		return err
+	}
+	host, _ := os.Hostname()
+	now := time.Now().UTC()
+	lock := &elasticsearchLockDoc{
+		Owner:    fmt.Sprintf("%v:%v", host, os.Getpid()),
+		Acquired: now,
+		Expires:  now.Add(elasticsearchLockLease),
+	}
+	path := r.lockPath() + "?op_type=create"
+	existing, err := r.getLock()
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if now.Before(existing.Expires) {
+			return fmt.Errorf("registry in %v is locked by %v until %v (another o365beat is using its document_id)", r, existing.Owner, existing.Expires)
+		}
+		logp.Warn("taking over expired registry lock in %v held by %v", r, existing.Owner)
+		path = fmt.Sprintf("%v?if_seq_no=%v&if_primary_term=%v", r.lockPath(), existing.seqNo, existing.primaryTerm)
+	}
+	if err := r.putLock(path, lock); err != nil {
+		return err
+	}
+	r.lock = lock
+	r.lockStop, r.lockDone = make(chan struct{}), make(chan struct{})
+	go r.renewLock()
+	return nil
+}
+
+// renewLock extends the lock every third of its lease until Close
+func (r *elasticsearchRegistry) renewLock() {
+	defer close(r.lockDone)
+	ticker := time.NewTicker(elasticsearchLockLease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.lockStop:
+			return
+		case <-ticker.C:
+		}
+		r.lock.Expires = time.Now().UTC().Add(elasticsearchLockLease)
+		path := fmt.Sprintf("%v?if_seq_no=%v&if_primary_term=%v", r.lockPath(), r.lock.seqNo, r.lock.primaryTerm)
+		if err := r.putLock(path, r.lock); err != nil {
+			// saves still fail if another beat took over and wrote the registry
+			logp.Err("error renewing registry lock in %v: %v", r, err)
+		}
+	}
+}
+
+// getLock returns the lock document, or nil if there's none
+func (r *elasticsearchRegistry) getLock() (*elasticsearchLockDoc, error) {
+	status, body, err := r.request("GET", r.lockPath(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("error getting registry lock from %v: %v %s", r, status, body)
+	}
+	var res struct {
+		SeqNo       int64                `json:"_seq_no"`
+		PrimaryTerm int64                `json:"_primary_term"`
+		Source      elasticsearchLockDoc `json:"_source"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("error decoding registry lock from %v: %v", r, err)
+	}
+	res.Source.seqNo, res.Source.primaryTerm = res.SeqNo, res.PrimaryTerm
+	return &res.Source, nil
+}
+
+// putLock writes the lock document (path has its op_type or if_seq_no),
+// updating its sequence number
+func (r *elasticsearchRegistry) putLock(path string, lock *elasticsearchLockDoc) error {
+	body, err := json.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	status, res, err := r.request("PUT", path, body)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusConflict {
+		return fmt.Errorf("registry in %v was locked by another o365beat meanwhile: %s", r, res)
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		return fmt.Errorf("error writing registry lock to %v: %v %s", r, status, res)
+	}
+	var saved struct {
+		SeqNo       int64 `json:"_seq_no"`
+		PrimaryTerm int64 `json:"_primary_term"`
+	}
+	if err := json.Unmarshal(res, &saved); err != nil {
+		return fmt.Errorf("error decoding registry lock response from %v: %v", r, err)
+	}
+	lock.seqNo, lock.primaryTerm = saved.SeqNo, saved.PrimaryTerm
+	return nil
+}
+
+// request sends a request to each host in turn until one responds, returning
+// the response's status and body
+func (r *elasticsearchRegistry) request(method, path string, body []byte) (int, []byte, error) {
+	var lastErr error
+	for _, host := range r.config.Hosts {
+		req, err := http.NewRequest(method, strings.TrimRight(host, "/")+path, bytes.NewReader(body))
+		if err != nil {
+			return 0, nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if r.config.APIKey != "" {
+			req.Header.Set("Authorization", "ApiKey "+base64.StdEncoding.EncodeToString([]byte(r.config.APIKey)))
+		} else if r.config.Username != "" {
+			req.SetBasicAuth(r.config.Username, r.config.Password)
+		}
+		res, err := r.httpClient.Do(req)
+		if err != nil {
+			logp.Warn("error reaching registry host %v: %v", host, err)
+			lastErr = err
+			continue
+		}
+		data, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return res.StatusCode, data, nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no registry.elasticsearch.hosts configured")
+	}
+	return 0, nil, lastErr
+}
+
+// Close stops renewing the lock, if it's held, and deletes it
+func (r *elasticsearchRegistry) Close() error {
+	if r.lock == nil {
+		return nil
+	}
+	close(r.lockStop)
+	<-r.lockDone
+	path := fmt.Sprintf("%v?if_seq_no=%v&if_primary_term=%v", r.lockPath(), r.lock.seqNo, r.lock.primaryTerm)
+	r.lock = nil
+	status, res, err := r.request("DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNotFound {
+		return fmt.Errorf("error removing registry lock from %v: %v %s", r, status, res)
+	}
+	return nil
+}
+
+func (r *elasticsearchRegistry) String() string {
+	return fmt.Sprintf("elasticsearch:%v%v", strings.Join(r.config.Hosts, ","), r.docPath())
+}
@@ -0,0 +1,83 @@
+package beater
+
+import (
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	kvBucket     = []byte("registry")
+	kvCurrentKey = []byte("current")
+	kvBackupKey  = []byte("backup")
+)
+
+// kvRegistry stores the registry in an embedded key-value database (bbolt),
+// which is only opened by one process at a time
+type kvRegistry struct {
+	db   *bolt.DB
+	path string
+}
+
+func openKVRegistry(path string) (*kvRegistry, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("error opening registry database %v (is another o365beat using it?): %v", path, err)
+	}
+	return &kvRegistry{db: db, path: path}, nil
+}
+
+func (r *kvRegistry) Load() ([]byte, error) {
+	return r.get(kvCurrentKey)
+}
+
+func (r *kvRegistry) LoadBackup() ([]byte, error) {
+	return r.get(kvBackupKey)
+}
+
+// get returns a copy of the key's value (values are only valid in the transaction)
+func (r *kvRegistry) get(key []byte) ([]byte, error) {
+	var data []byte
+	err := r.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(kvBucket)
+		if b == nil {
+			return nil
+		}
+		if v := b.Get(key); v != nil {
+			data = append([]byte{}, v...)
+		}
+		return nil
+	})
+	return data, err
+}
+
+// Save replaces the registry and its backup in one transaction
+func (r *kvRegistry) Save(data []byte) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(kvBucket)
+		if err != nil {
+			return err
+		}
+		if current := b.Get(kvCurrentKey); current != nil {
+			if err := b.Put(kvBackupKey, append([]byte{}, current...)); err != nil {
+				return err
+			}
+		}
+		return b.Put(kvCurrentKey, data)
+	})
+}
+
+// Lock does nothing: bbolt holds an exclusive lock on the database file while
+// it's open, so a second beat can't open it
+func (r *kvRegistry) Lock() error {
+	return nil
+}
+
+func (r *kvRegistry) Close() error {
+	return r.db.Close()
+}
+
+func (r *kvRegistry) String() string {
+	return "kv:" + r.path
+}
@@ -0,0 +1,127 @@
+package beater
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/elastic/beats/libbeat/logp"
+
+	"github.com/counteractive/o365beat/config"
+)
+
+// Registry stores the encoded registry between runs (see registry.type).
+// backends keep the registry each save replaces as a backup, for the
+// registry_on_corruption policy.
+type Registry interface {
+	// Load returns the stored registry, or nil if nothing is stored yet
+	Load() ([]byte, error)
+	// LoadBackup returns the previously stored registry, or nil if there's none
+	LoadBackup() ([]byte, error)
+	// Save stores the registry, keeping the one it replaces as the backup
+	Save(data []byte) error
+	// Lock claims the registry for writing until Close, failing if another
+	// process has it.  it's called before the first Save (readers don't lock).
+	Lock() error
+	Close() error
+	// String describes where the registry is stored, for log messages
+	String() string
+}
+
+// openRegistryStore opens the configured registry backend
+func openRegistryStore(c config.Config) (Registry, error) {
+	switch c.Registry.Type {
+	case config.RegistryTypeKV:
+		return openKVRegistry(c.Registry.KV.Path)
+	case config.RegistryTypeElasticsearch:
+		return newElasticsearchRegistry(c.Registry.Elasticsearch), nil
+	case config.RegistryTypeFile, "":
+		return &fileRegistry{path: c.RegistryFilePath}, nil
+	}
+	return nil, fmt.Errorf("unknown registry.type %q", c.Registry.Type)
+}
+
+// fileRegistry stores the registry in a json file, replaced atomically on each
+// save with the previous version kept alongside it (.bak)
+type fileRegistry struct {
+	path string
+}
+
+// Load reads the registry file, or its backup if it's missing (e.g., after a
+// crash mid-save)
+func (r *fileRegistry) Load() ([]byte, error) {
+	data, err := ioutil.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		data, err = r.LoadBackup()
+		if data != nil {
+			logp.Warn("registry file (%v) is missing, using its backup", r.path)
+		}
+	}
+	return data, err
+}
+
+func (r *fileRegistry) LoadBackup() ([]byte, error) {
+	data, err := ioutil.ReadFile(r.path + ".bak")
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Save writes and syncs the registry to a temporary file, keeps the current one
+// as the backup, and renames the temporary file into place
+func (r *fileRegistry) Save(data []byte) error {
+	if err := writeFileSync(r.path+".tmp", data); err != nil {
+		return err
+	}
+	if err := os.Rename(r.path, r.path+".bak"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Rename(r.path+".tmp", r.path); err != nil {
+		return err
+	}
+	syncDir(filepath.Dir(r.path))
+	return nil
+}
+
+// Lock does nothing: the registry lock next to the file already covers it
+func (r *fileRegistry) Lock() error {
+	return nil
+}
+
+func (r *fileRegistry) Close() error {
+	return nil
+}
+
+func (r *fileRegistry) String() string {
+	return r.path
+}
+
+// writeFileSync writes data to a file and syncs it to disk
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// syncDir syncs a directory so renames in it are on disk (best effort, it's
+// not supported everywhere, e.g. windows)
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	d.Sync()
+	d.Close()
+}
@@ -0,0 +1,231 @@
+// +build !integration
+
+package beater
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/counteractive/o365beat/config"
+)
+
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "o365beat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+// testRegistryRoundTrip saves two registries and checks Load and LoadBackup
+func testRegistryRoundTrip(t *testing.T, r Registry) {
+	if data, err := r.Load(); data != nil || err != nil {
+		t.Fatalf("empty registry: got %q, %v", data, err)
+	}
+	if data, err := r.LoadBackup(); data != nil || err != nil {
+		t.Fatalf("empty registry backup: got %q, %v", data, err)
+	}
+	for _, data := range []string{`{"version":2,"n":1}`, `{"version":2,"n":2}`} {
+		if err := r.Save([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if data, err := r.Load(); string(data) != `{"version":2,"n":2}` || err != nil {
+		t.Fatalf("Load: got %q, %v", data, err)
+	}
+	if data, err := r.LoadBackup(); string(data) != `{"version":2,"n":1}` || err != nil {
+		t.Fatalf("LoadBackup: got %q, %v", data, err)
+	}
+}
+
+func TestFileRegistry(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	r := &fileRegistry{path: filepath.Join(dir, "o365beat.state")}
+	testRegistryRoundTrip(t, r)
+
+	// a missing registry (e.g., a crash between renames) falls back to the backup
+	if err := os.Remove(r.path); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := r.Load(); string(data) != `{"version":2,"n":1}` || err != nil {
+		t.Fatalf("Load without the registry: got %q, %v", data, err)
+	}
+}
+
+func TestKVRegistry(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "o365beat.db")
+	r, err := openKVRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testRegistryRoundTrip(t, r)
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err = openKVRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if data, err := r.Load(); string(data) != `{"version":2,"n":2}` || err != nil {
+		t.Fatalf("Load after reopening: got %q, %v", data, err)
+	}
+}
+
+// fakeElasticsearch is enough of elasticsearch's document api for the registry
+type fakeElasticsearch struct {
+	mutex sync.Mutex
+	docs  map[string]fakeDoc // by path
+	seqNo int64
+	gets  int
+}
+
+type fakeDoc struct {
+	source json.RawMessage
+	seqNo  int64
+}
+
+func newFakeElasticsearch() *fakeElasticsearch {
+	return &fakeElasticsearch{docs: map[string]fakeDoc{}}
+}
+
+func (es *fakeElasticsearch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+	if r.Header.Get("Authorization") != "ApiKey aWQ6a2V5" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if !strings.Contains(r.URL.Path, "/_doc/") {
+		w.Write([]byte(`{"acknowledged":true}`)) // creating the index
+		return
+	}
+	doc, exists := es.docs[r.URL.Path]
+	switch r.Method {
+	case "GET":
+		es.gets++
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"_seq_no":` + strconv.FormatInt(doc.seqNo, 10) + `,"_primary_term":1,"_source":` + string(doc.source) + `}`))
+	case "PUT":
+		q := r.URL.Query()
+		if q.Get("op_type") == "create" && exists ||
+			q.Get("if_seq_no") != "" && (!exists || q.Get("if_seq_no") != strconv.FormatInt(doc.seqNo, 10) || q.Get("if_primary_term") != "1") {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":{"type":"version_conflict_engine_exception"}}`))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		es.seqNo++
+		es.docs[r.URL.Path] = fakeDoc{source: body, seqNo: es.seqNo}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"_seq_no":` + strconv.FormatInt(es.seqNo, 10) + `,"_primary_term":1}`))
+	case "DELETE":
+		delete(es.docs, r.URL.Path)
+		w.Write([]byte(`{"result":"deleted"}`))
+	}
+}
+
+func newTestElasticsearchRegistry(url string) *elasticsearchRegistry {
+	return newElasticsearchRegistry(config.ElasticsearchRegistryConfig{
+		Hosts:      []string{"http://127.0.0.1:1", url}, // the first is unreachable
+		Index:      "o365beat-registry",
+		DocumentID: "o365beat",
+		APIKey:     "id:key",
+		Timeout:    time.Second,
+	})
+}
+
+func TestElasticsearchRegistry(t *testing.T) {
+	es := newFakeElasticsearch()
+	srv := httptest.NewServer(es)
+	defer srv.Close()
+
+	testRegistryRoundTrip(t, newTestElasticsearchRegistry(srv.URL))
+
+	// saves keep the document they wrote, rather than reading it back
+	r := newTestElasticsearchRegistry(srv.URL)
+	if _, err := r.Load(); err != nil {
+		t.Fatal(err)
+	}
+	gets := es.gets
+	for i := 0; i < 3; i++ {
+		if err := r.Save([]byte(`{"version":2}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if es.gets != gets {
+		t.Fatalf("saves read the registry %v time(s)", es.gets-gets)
+	}
+}
+
+func TestElasticsearchRegistryConflict(t *testing.T) {
+	srv := httptest.NewServer(newFakeElasticsearch())
+	defer srv.Close()
+
+	a, b := newTestElasticsearchRegistry(srv.URL), newTestElasticsearchRegistry(srv.URL)
+	if err := a.Save([]byte(`{"n":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Save([]byte(`{"n":2}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Save([]byte(`{"n":3}`)); err == nil {
+		t.Fatal("saving over another writer's registry succeeded")
+	}
+	if data, _ := b.Load(); string(data) != `{"n":2}` {
+		t.Fatalf("got %q", data)
+	}
+}
+
+func TestElasticsearchRegistryLock(t *testing.T) {
+	es := newFakeElasticsearch()
+	srv := httptest.NewServer(es)
+	defer srv.Close()
+
+	a, b := newTestElasticsearchRegistry(srv.URL), newTestElasticsearchRegistry(srv.URL)
+	if err := a.Lock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Lock(); err == nil {
+		t.Fatal("locked a registry that's already locked")
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Lock(); err != nil {
+		t.Fatalf("locking a released registry: %v", err)
+	}
+	b.Close()
+
+	// a lock left behind (e.g., by a crash) is taken over once it expires
+	es.mutex.Lock()
+	es.seqNo++
+	es.docs[b.lockPath()] = fakeDoc{
+		source: json.RawMessage(`{"owner":"crashed:1","expires":"` + time.Now().Add(-time.Second).Format(time.RFC3339) + `"}`),
+		seqNo:  es.seqNo,
+	}
+	es.mutex.Unlock()
+	if err := a.Lock(); err != nil {
+		t.Fatalf("taking over an expired lock: %v", err)
+	}
+	a.Close()
+}
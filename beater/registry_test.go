@@ -0,0 +1,93 @@
+// +build !integration
+
+package beater
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/counteractive/o365beat/config"
+)
+
+// testV1Registry returns a beat whose file registry is a copy of the version 1
+// fixture (a bare timestamp), collecting for tenants
+func testV1Registry(t *testing.T, dir string, tenants ...config.TenantConfig) *O365beat {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "o365beat.v1.state"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := config.DefaultConfig
+	c.DirectoryID = "d"
+	c.RegistryFilePath = filepath.Join(dir, "o365beat.state")
+	if err := ioutil.WriteFile(c.RegistryFilePath, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return &O365beat{config: c, tenantConfigs: tenants, store: &fileRegistry{path: c.RegistryFilePath}}
+}
+
+func TestMigrateV1Registry(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	contentTypes := []string{"Audit.General", "Audit.Exchange"}
+	bt := testV1Registry(t, dir,
+		config.TenantConfig{Name: "fabrikam", DirectoryID: "f", RegistryNamespace: "f", ContentTypes: contentTypes},
+		config.TenantConfig{Name: "contoso", DirectoryID: "d", RegistryNamespace: "ns", ContentTypes: contentTypes},
+	)
+
+	reg, err := bt.getRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the timestamp belongs to the top-level tenant, and the cursors start
+	// just after it, as the version 1 registry did
+	lastProcessed := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	want := &registry{Version: registryVersion, Tenants: map[string]tenantCursors{"ns": {
+		"Audit.General":  cursor{ContentCreated: lastProcessed},
+		"Audit.Exchange": cursor{ContentCreated: lastProcessed},
+	}}}
+	if !reflect.DeepEqual(reg, want) {
+		t.Fatalf("got %+v, want %+v", reg, want)
+	}
+	for contentType, c := range reg.Tenants["ns"] {
+		if c.Processed != nil || !c.start(time.Hour).Equal(lastProcessed.Add(time.Second)) {
+			t.Errorf("%v: got processed %v, starting at %v", contentType, c.Processed, c.start(time.Hour))
+		}
+	}
+
+	if err := bt.putRegistry(reg); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(bt.config.RegistryFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &saved); err != nil || saved.Version != registryVersion {
+		t.Errorf("re-saved registry %s, want version %v", data, registryVersion)
+	}
+	if reloaded, err := bt.getRegistry(); err != nil || !reflect.DeepEqual(reloaded, want) {
+		t.Errorf("reloaded %+v (%v), want %+v", reloaded, err, want)
+	}
+}
+
+func TestMigrateV1RegistryWithoutTenant(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	bt := testV1Registry(t, dir,
+		config.TenantConfig{Name: "fabrikam", DirectoryID: "f", RegistryNamespace: "f"},
+		config.TenantConfig{Name: "northwind", DirectoryID: "n", RegistryNamespace: "n"},
+	)
+	reg, err := bt.getRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reg.Tenants) != 0 {
+		t.Errorf("got %+v, want an empty registry", reg)
+	}
+}
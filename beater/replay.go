@@ -0,0 +1,207 @@
+package beater
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+
+	"github.com/counteractive/o365beat/config"
+	"github.com/counteractive/o365beat/o365api"
+)
+
+// ListDeadLetters unpacks the beat's config and returns its dead letters,
+// oldest content first
+func ListDeadLetters(cfg *common.Config) ([]DeadLetter, error) {
+	bt, err := newO365beat(cfg)
+	if err != nil {
+		return nil, err
+	}
+	store, err := openDeadLetterStore(bt.config.DeadLetterPath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening dead-letter store %v: %v", bt.config.DeadLetterPath, err)
+	}
+	return store.list(), nil
+}
+
+// ReplayOptions selects the dead letters replayed
+type ReplayOptions struct {
+	ContentTypes []string // defaults to all
+	Tenants      []string // tenant names, defaults to all configured tenants
+}
+
+// Replay publishes dead letters, then exits once they've been acknowledged by
+// the output: blobs are downloaded again (even if they've failed many times),
+// and events are published with their timestamp_fallback timestamp (their
+// blob's contentCreated if it's none).  replayed dead letters are removed from
+// the store once acknowledged; those that fail again stay in it.  it holds the
+// registry lock, so it can't run alongside the beat, but it doesn't update the
+// registry.
+type Replay struct {
+	done          chan struct{}
+	config        config.Config
+	tenantConfigs []config.TenantConfig
+	options       ReplayOptions
+	tenants       []*tenant
+}
+
+// NewReplay returns a beat.Creator for a replay with the given options
+func NewReplay(options ReplayOptions) beat.Creator {
+	return func(b *beat.Beat, cfg *common.Config) (beat.Beater, error) {
+		bt, err := newO365beat(cfg)
+		if err != nil {
+			return nil, err
+		}
+		tenants, err := selectTenants(bt.tenantConfigs, options.Tenants)
+		if err != nil {
+			logp.Error(err)
+			return nil, err
+		}
+		c := bt.config
+		if c.TimestampFallback == config.TimestampFallbackNone {
+			c.TimestampFallback = config.TimestampFallbackContentCreated
+		}
+		return &Replay{
+			done:          make(chan struct{}),
+			config:        c,
+			tenantConfigs: tenants,
+			options:       options,
+		}, nil
+	}
+}
+
+// Run replays each tenant's dead letters in turn, then waits for the output to
+// acknowledge everything published.
+func (bt *Replay) Run(b *beat.Beat) error {
+	lock, err := lockRegistry(bt.config.RegistryFilePath)
+	if err != nil {
+		logp.Error(err)
+		return err
+	}
+	defer lock.unlock()
+
+	store, err := openDeadLetterStore(bt.config.DeadLetterPath)
+	if err != nil {
+		err = fmt.Errorf("error opening dead-letter store %v: %v", bt.config.DeadLetterPath, err)
+		logp.Error(err)
+		return err
+	}
+
+	// a throwaway registry, as for backfills: it only tracks acknowledgements
+	registrar := newRegistrar(newRegistry(), bt.config.ContentOverlap, func([]byte) error { return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-bt.done
+		cancel()
+	}()
+	defer func() {
+		for _, t := range bt.tenants {
+			t.client.Close()
+		}
+	}()
+
+	for _, tc := range bt.tenantConfigs {
+		t, err := newTenant(bt.config, tc, registrar, store)
+		if err != nil {
+			logp.Error(err)
+			return err
+		}
+		t.client, err = b.Publisher.ConnectWith(beat.ClientConfig{
+			PublishMode: beat.GuaranteedSend,
+			ACKEvents:   registrar.ackEvents,
+		})
+		if err != nil {
+			logp.Error(err)
+			return err
+		}
+		bt.tenants = append(bt.tenants, t)
+
+		if err := bt.replay(ctx, t, store.list()); err != nil {
+			return err
+		}
+	}
+
+	logp.Info("dead letters published, waiting for the output to acknowledge all events")
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for !registrar.drained() {
+		select {
+		case <-ctx.Done():
+			logp.Warn("replay stopped before all events were acknowledged")
+			return nil
+		case <-ticker.C:
+		}
+	}
+	logp.Info("replay complete, %v dead letter(s) remain in %v", len(store.list()), bt.config.DeadLetterPath)
+	return nil
+}
+
+// replay publishes a tenant's selected dead letters
+func (bt *Replay) replay(ctx context.Context, t *tenant, letters []DeadLetter) error {
+	var blobs []o365api.Content
+	events := map[string][]DeadLetter{} // by content id
+	var order []string
+	for _, l := range letters {
+		if l.Tenant != t.config.RegistryNamespace || !bt.selects(l.ContentType) {
+			continue
+		}
+		switch l.Kind {
+		case DeadLetterBlob:
+			blobs = append(blobs, l.content())
+		case DeadLetterEvent:
+			if _, ok := events[l.ContentID]; !ok {
+				order = append(order, l.ContentID)
+			}
+			events[l.ContentID] = append(events[l.ContentID], l)
+		}
+	}
+	logp.Info("replaying %v blob(s) and the events of %v blob(s) for %v", len(blobs), len(order), t.config.Name)
+
+	if err := t.process(ctx, blobs, true); err != nil {
+		logp.Err("error replaying dead-lettered blobs for %v: %v", t.config.Name, err)
+		return err
+	}
+	for _, contentID := range order {
+		replayed := events[contentID]
+		content := make([]common.MapStr, 0, len(replayed))
+		for _, l := range replayed {
+			content = append(content, l.Event)
+		}
+		// timestamp_fallback is never none in replays, so none are dead-lettered again
+		events, err := t.timestampEvents(replayed[0].content(), content)
+		if err != nil {
+			return err
+		}
+		blob := t.registrar.add(t.config.RegistryNamespace, replayed[0].content(), true)
+		blob.onAcked = func() {
+			if err := t.deadLetters.remove(replayed...); err != nil {
+				logp.Err("error writing dead-letter store %v: %v", t.deadLetters.path, err)
+			}
+		}
+		t.registrar.published(blob, t.publish(events, blob))
+	}
+	return nil
+}
+
+// selects reports whether the content type's dead letters are replayed
+func (bt *Replay) selects(contentType string) bool {
+	if len(bt.options.ContentTypes) == 0 {
+		return true
+	}
+	for _, c := range bt.options.ContentTypes {
+		if c == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// Stop stops the replay (Run closes the pipeline clients once it's stopped).
+func (bt *Replay) Stop() {
+	close(bt.done)
+}
@@ -0,0 +1,724 @@
+package beater
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+
+	"github.com/counteractive/o365beat/config"
+	"github.com/counteractive/o365beat/o365api"
+)
+
+// tenant polls the API for a single tenant (see config.TenantConfig), publishing
+// with its own pipeline client and sharing the beat's registrar
+type tenant struct {
+	config         config.TenantConfig
+	global         config.Config // beat-wide settings
+	api            *o365api.Client
+	client         beat.Client
+	registrar      *registrar
+	notifications  chan []o365api.Content // content announced to the webhook (see webhookServer)
+	throttledUntil time.Time              // polls are skipped until then after being throttled
+	webhookPending bool                   // registering the webhook failed, it's retried each tick
+	resubscribe    bool                   // restart missing subscriptions when listing (not in backfills)
+	ecs            ecsMapper              // nil if ecs.enabled is false
+	enums          *enumEnricher          // nil if enums.enabled is false
+	properties     *propertyDecoder       // nil if properties.enabled is false
+	deadLetters    *deadLetterStore       // nil if dead letters are only logged (backfills)
+}
+
+// minThrottleBackoff is the least time a tenant waits to poll again after its
+// requests were throttled (beyond the api client's own retries)
+const minThrottleBackoff = 5 * time.Minute
+
+// notificationBacklog is how many webhook notifications may wait for a tenant
+// (e.g., during a long poll) before more are dropped and left to the next poll
+const notificationBacklog = 64
+
+// tokenCaches holds the token cache for each token_cache.path, so the
+// process's api clients share one (see sharedTokenCache)
+var (
+	tokenCachesMutex sync.Mutex
+	tokenCaches      = map[string]*o365api.TokenCache{}
+)
+
+// sharedTokenCache returns the token cache configured with token_cache, nil if
+// it's disabled
+func sharedTokenCache(c config.TokenCacheConfig) (*o365api.TokenCache, error) {
+	if c.Path == "" {
+		return nil, nil
+	}
+	tokenCachesMutex.Lock()
+	defer tokenCachesMutex.Unlock()
+	if cache, ok := tokenCaches[c.Path]; ok {
+		return cache, nil
+	}
+	cache, err := o365api.NewTokenCache(c.Path, c.Key)
+	if err != nil {
+		return nil, err
+	}
+	tokenCaches[c.Path] = cache
+	return cache, nil
+}
+
+// newAPIClient creates a management activity api client for a tenant
+func newAPIClient(c config.Config, t config.TenantConfig) (*o365api.Client, error) {
+	cache, err := sharedTokenCache(c.TokenCache)
+	if err != nil {
+		return nil, err
+	}
+	return o365api.NewClient(o365api.Config{
+		TenantDomain:        t.TenantDomain,
+		DirectoryID:         t.DirectoryID,
+		ClientID:            t.ClientID,
+		AuthMode:            t.Auth.Mode,
+		ClientSecret:        t.ClientSecret,
+		ClientSecretPath:    t.ClientSecretFile,
+		CertificatePath:     t.CertificatePath,
+		CertificateKeyPath:  t.CertificateKeyPath,
+		CertificatePassword: t.CertificatePwd,
+		ClientAssertionPath: t.ClientAssertionPath,
+		Cloud:               c.Cloud,
+		LoginURL:            c.LoginURL,
+		ResourceURL:         c.ResourceURL,
+		Timeout:             c.APITimeout,
+		Retry:               o365api.RetryPolicy(c.APIRetry),
+		TokenCache:          cache,
+		Logger:              logp.NewLogger("api").With("tenant", t.Name),
+	})
+}
+
+// TenantAPI is a configured tenant's api client, for commands that manage the
+// api directly rather than running the beat
+type TenantAPI struct {
+	Name         string
+	ContentTypes []string         // configured content types
+	Webhook      *o365api.Webhook // webhook to register with subscriptions, nil if disabled
+	Client       *o365api.Client
+}
+
+// NewTenantAPIs unpacks the beat's config and returns an api client for each of
+// the named tenants (all configured tenants if names is empty)
+func NewTenantAPIs(cfg *common.Config, names []string) ([]TenantAPI, error) {
+	bt, err := newO365beat(cfg)
+	if err != nil {
+		return nil, err
+	}
+	c := bt.config
+	tenants, err := selectTenants(bt.tenantConfigs, names)
+	if err != nil {
+		return nil, err
+	}
+	apis := make([]TenantAPI, 0, len(tenants))
+	for _, t := range tenants {
+		client, err := newAPIClient(c, t)
+		if err != nil {
+			return nil, err
+		}
+		apis = append(apis, TenantAPI{Name: t.Name, ContentTypes: t.ContentTypes, Webhook: webhookFor(c), Client: client})
+	}
+	return apis, nil
+}
+
+func newTenant(c config.Config, tc config.TenantConfig, r *registrar, dl *deadLetterStore) (*tenant, error) {
+	api, err := newAPIClient(c, tc)
+	if err != nil {
+		return nil, err
+	}
+	ecs, err := newECSMapper(c.ECS)
+	if err != nil {
+		return nil, err
+	}
+	enums, err := newEnumEnricher(c.Enums)
+	if err != nil {
+		return nil, err
+	}
+	return &tenant{
+		config:        tc,
+		global:        c,
+		api:           api,
+		registrar:     r,
+		notifications: make(chan []o365api.Content, notificationBacklog),
+		ecs:           ecs,
+		enums:         enums,
+		properties:    newPropertyDecoder(c.Properties),
+		deadLetters:   dl,
+		resubscribe:   true,
+	}, nil
+}
+
+// run polls the api every period until ctx is cancelled, handling webhook
+// notifications in between.  errors are logged and retried next period, so one
+// tenant's problems don't affect the others.
+func (t *tenant) run(ctx context.Context) {
+	logp.Info("polling tenant %v (%v) every %v", t.config.Name, t.config.DirectoryID, t.config.Period)
+	ticker := time.NewTicker(t.config.Period)
+	defer ticker.Stop()
+
+	// tokens are refreshed ahead of expiry, so polls and downloads don't wait on them
+	go t.api.RefreshTokens(ctx)
+
+	// ticker's first tick is AFTER its period, so tick once up front
+	subscribed := t.tick(ctx, false)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			subscribed = t.tick(ctx, subscribed)
+		case notified := <-t.notifications:
+			if err := t.push(ctx, notified); err != nil {
+				logp.Err("error getting notified content for %v, will catch up next poll: %v", t.config.Name, err)
+			}
+		}
+	}
+}
+
+// tick enables subscriptions (until that succeeds) and polls, returning
+// whether subscriptions are enabled
+func (t *tenant) tick(ctx context.Context, subscribed bool) bool {
+	if !subscribed || t.webhookPending {
+		if err := t.enableSubscriptions(ctx); err != nil {
+			logp.Err("error enabling subscriptions for %v, will retry: %v", t.config.Name, err)
+			return false
+		}
+	}
+	if now := time.Now(); now.Before(t.throttledUntil) {
+		logp.Info("skipping poll of %v until %v, after being throttled", t.config.Name, t.throttledUntil)
+		return true
+	}
+	if err := t.poll(ctx); err != nil {
+		logp.Err("error polling %v, will retry: %v", t.config.Name, err)
+		if o365api.IsThrottled(err) {
+			t.throttledUntil = time.Now().Add(throttleBackoff(err))
+		}
+	}
+	return true
+}
+
+// throttleBackoff is how long a tenant waits to poll again after being
+// throttled: the API's Retry-After, or minThrottleBackoff if that's shorter
+func throttleBackoff(err error) time.Duration {
+	if e, ok := err.(*o365api.APIError); ok && e.RetryAfter > minThrottleBackoff {
+		return e.RetryAfter
+	}
+	return minThrottleBackoff
+}
+
+// collects reports whether the content type is configured for this tenant
+func (t *tenant) collects(contentType string) bool {
+	for _, c := range t.config.ContentTypes {
+		if c == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookFor returns the webhook to register with subscriptions (nil if disabled)
+func webhookFor(c config.Config) *o365api.Webhook {
+	if !c.Webhook.Enabled {
+		return nil
+	}
+	return &o365api.Webhook{Address: c.Webhook.Address, AuthID: c.Webhook.AuthID}
+}
+
+// enableSubscriptions enables subscriptions for all configured contentTypes
+func (t *tenant) enableSubscriptions(ctx context.Context) error {
+	logp.Info("enabling subscriptions for %v for configured content types: %v", t.config.Name, t.config.ContentTypes)
+	subscriptions, err := t.api.ListSubscriptions(ctx)
+	if err != nil {
+		logp.Error(err)
+		return err
+	}
+
+	// add subscriptions as "disabled" if not in listSubscription results (can return []!):
+	for _, contentType := range t.config.ContentTypes {
+		found := false
+		for _, sub := range subscriptions {
+			if sub.ContentType == contentType {
+				logp.Debug("api", "found subscription for contentType %s (enabled or disabled)", contentType)
+				found = true
+				break
+			}
+		}
+		if !found {
+			logp.Debug("api", "no subscription for configured contentType %s, appending to list to subscribe", contentType)
+			subscriptions = append(subscriptions, o365api.Subscription{ContentType: contentType, Status: "disabled"})
+		}
+	}
+
+	webhook := webhookFor(t.global)
+	t.webhookPending = false
+	for _, sub := range subscriptions {
+		if sub.Status != "enabled" {
+			logp.Info("subscribing to content type %v", sub.ContentType)
+			logp.Info("note that new subscriptions can take up to 12 hours to produce data")
+			if err := t.startSubscription(ctx, sub.ContentType); err != nil {
+				logp.Error(err)
+				return err
+			}
+		} else if webhook != nil && !sameWebhook(sub.Webhook, webhook) {
+			// starting an enabled subscription updates its webhook; if that fails
+			// the subscription is still enabled, so polling carries on
+			logp.Info("registering webhook %v for content type %v", webhook.Address, sub.ContentType)
+			if _, err := t.api.StartSubscription(ctx, sub.ContentType, webhook); err != nil {
+				logp.Warn("error registering webhook for content type %v, polling only until it's retried next period: %v", sub.ContentType, err)
+				t.webhookPending = true
+			}
+		}
+	}
+	return nil
+}
+
+// startSubscription starts a content type's subscription with the webhook, if
+// it's enabled.  if the webhook can't be registered (e.g., the api can't reach
+// it to validate it), the subscription is started without it so polling still
+// collects the content, and registering the webhook is retried next period.
+func (t *tenant) startSubscription(ctx context.Context, contentType string) error {
+	webhook := webhookFor(t.global)
+	_, err := t.api.StartSubscription(ctx, contentType, webhook)
+	if err == nil || webhook == nil {
+		return err
+	}
+	logp.Warn("error registering webhook for content type %v, polling only until it's retried next period: %v", contentType, err)
+	t.webhookPending = true
+	_, err = t.api.StartSubscription(ctx, contentType, nil)
+	return err
+}
+
+// sameWebhook reports whether a subscription's (enabled) webhook is the one configured
+func sameWebhook(current, configured *o365api.Webhook) bool {
+	return current != nil &&
+		strings.EqualFold(current.Status, "enabled") &&
+		current.Address == configured.Address &&
+		current.AuthID == configured.AuthID
+}
+
+// listAvailableContent gets blob locations for a single content type over <=24 hour span
+// (the basic primitive provided by the API)
+func (t *tenant) listAvailableContent(ctx context.Context, contentType string, start, end time.Time) ([]o365api.Content, error) {
+	logp.Info("getting available content of type %s between %s and %s", contentType, start, end)
+	now := time.Now()
+	if now.Sub(start) > t.global.ContentMaxAge {
+		logp.Warn("start (%v) must be <=%v hrs ago, resetting", start, t.global.ContentMaxAge.Hours())
+		start = now.Add(-t.global.ContentMaxAge)
+	}
+
+	var contentList []o365api.Content
+	it := t.api.ListContent(ctx, contentType, start, end)
+	for it.Next() {
+		contentList = append(contentList, it.Content())
+	}
+	if err := it.Err(); err != nil {
+		logp.Error(err)
+		return nil, err
+	}
+	logp.Info(
+		"got %v available content locations of type %s between %s and %s",
+		len(contentList), contentType, start, end,
+	)
+	logp.Debug("api", "got this available content: %v", contentList)
+	return contentList, nil
+}
+
+// listAllAvailableContent gets blob locations for multiple content types and spans up to 7 days
+// sorted by contentCreated timestamp (uses the listAvailableContent function).  each content
+// type has its own start time (from its registry cursor), keyed by content type in starts.
+// content types that fail are logged and skipped, so one failing feed doesn't hold up the rest;
+// an error is only returned if all of them fail, or if one's subscription is missing and the
+// tenant doesn't resubscribe.
+func (t *tenant) listAllAvailableContent(ctx context.Context, starts map[string]time.Time, end time.Time) ([]o365api.Content, error) {
+	logp.Info("getting all available content until %s", end)
+	interval := o365api.MaxContentSpan
+	var contentList []o365api.Content
+	var lastErr error
+	failed := 0
+
+	// loop through all content types:
+	for contentType, start := range starts {
+		if !start.Before(end) {
+			logp.Debug("api", "no span to list for %v (start %v, end %v)", contentType, start, end)
+			continue
+		}
+
+		// loop through intervals this content type:
+		var list []o365api.Content
+		var err error
+		for iStart, iEnd := start, start; iStart.Before(end); iStart = iEnd {
+			iEnd = iStart.Add(interval)
+			if end.Before(iEnd) {
+				iEnd = end
+			}
+			var l []o365api.Content
+			l, err = t.listAvailableContent(ctx, contentType, iStart, iEnd)
+			if err != nil {
+				break
+			}
+			list = append(list, l...)
+			logp.Debug("api", "finished %v interval %v to %v", contentType, iStart, iEnd)
+		}
+		if o365api.IsSubscriptionMissing(err) && !t.resubscribe {
+			err = fmt.Errorf("subscription to %v for %v is missing or disabled (%v), start it with the subscriptions command or the beat: %v", contentType, t.config.Name, o365api.ErrorCode(err), err)
+			logp.Error(err)
+			return nil, err
+		}
+		if o365api.IsSubscriptionMissing(err) {
+			// e.g., an admin stopped it: restart it, and list its content next poll
+			logp.Warn("subscription to %v for %v is missing or disabled (%v), restarting it", contentType, t.config.Name, o365api.ErrorCode(err))
+			if err = t.startSubscription(ctx, contentType); err == nil {
+				continue
+			}
+		}
+		if o365api.IsThrottled(err) {
+			// listing the other content types would be throttled too
+			return nil, err
+		}
+		if err != nil {
+			logp.Warn("error listing available content of type %v for %v, skipping it this poll: %v", contentType, t.config.Name, err)
+			lastErr = err
+			failed++
+			continue
+		}
+		contentList = append(contentList, list...)
+	}
+	if failed > 0 && failed == len(starts) {
+		return nil, lastErr
+	}
+
+	logp.Debug("api", "got these available content locations: %v", contentList)
+	less := func(i, j int) bool {
+		return contentList[i].ContentCreated.Before(contentList[j].ContentCreated)
+	}
+	sorted := sort.SliceIsSorted(contentList, less)
+	if !sorted {
+		logp.Debug("api", "available content locations were unsorted; sorting by creation time")
+		sort.SliceStable(contentList, less)
+	}
+	return contentList, nil
+}
+
+// getContent gets actual content blobs
+func (t *tenant) getContent(ctx context.Context, urlStr string) ([]common.MapStr, error) {
+	logp.Debug("api", "getting content from %v.", urlStr)
+	events, err := t.api.GetContent(ctx, urlStr)
+	if err != nil {
+		logp.Error(err)
+		return nil, err
+	}
+	content := make([]common.MapStr, 0, len(events))
+	for _, evt := range events {
+		content = append(content, common.MapStr(evt))
+	}
+	return content, nil
+}
+
+// stampedEvent is an event with the timestamp it's published with
+type stampedEvent struct {
+	fields    common.MapStr
+	timestamp time.Time
+	fallback  string // the timestamp_fallback used, if any
+}
+
+// timestampEvents parses the CreationTime of a blob's events.  events without a
+// usable one get the timestamp_fallback timestamp (flagged in
+// o365.timestamp_fallback), or are kept in the dead-letter store, all of the
+// blob's at once, if it's none.  if they can't be kept, the error is returned
+// and none of the blob should be published, so the cursor can't move past it.
+func (t *tenant) timestampEvents(blob o365api.Content, content []common.MapStr) ([]stampedEvent, error) {
+	events := make([]stampedEvent, 0, len(content))
+	var letters []DeadLetter
+	fallbacks := 0
+	var fallbackErr error
+	for _, evt := range content {
+		ts, err := parseCreationTime(evt["CreationTime"])
+		fallback := ""
+		if err != nil {
+			if ts, fallback = t.fallbackTimestamp(blob); fallback == "" {
+				letters = append(letters, newEventDeadLetter(t.config.RegistryNamespace, blob, evt, err.Error()))
+				continue
+			}
+			fallbacks++
+			fallbackErr = err
+		}
+		events = append(events, stampedEvent{fields: evt, timestamp: ts, fallback: fallback})
+	}
+	if len(letters) > 0 {
+		if err := t.deadLetter(letters...); err != nil {
+			return nil, err
+		}
+		deadLettered.Add(int64(len(letters)))
+	}
+	if fallbacks > 0 {
+		timestampFallbacks.Add(int64(fallbacks))
+		logp.Warn("%v event(s) of %v blob %v had no usable CreationTime (e.g., %v), using their %v",
+			fallbacks, blob.ContentType, blob.ContentID, fallbackErr, t.global.TimestampFallback)
+	}
+	return events, nil
+}
+
+// publish sends events into the beats pipeline, attaching the pending blob so
+// the registrar can track acknowledgements.  it returns the number of events
+// published.
+func (t *tenant) publish(events []stampedEvent, pending *pendingBlob) int {
+	logp.Debug("beat", "publishing %v event(s)", len(events))
+	for _, evt := range events {
+		fs := common.MapStr{}
+		for k, v := range evt.fields {
+			fs[k] = v
+		}
+		fs.Put("o365.tenant.name", t.config.Name)
+		fs.Put("o365.tenant.id", t.config.DirectoryID)
+		if evt.fallback != "" {
+			fs.Put("o365.timestamp_fallback", evt.fallback)
+		}
+		if t.enums != nil {
+			t.enums.enrich(fs)
+		}
+		if t.properties != nil {
+			t.properties.decode(fs)
+		}
+		if t.ecs != nil {
+			t.ecs(fs)
+		}
+		t.client.Publish(beat.Event{Timestamp: evt.timestamp, Fields: fs, Private: pending})
+	}
+	return len(events)
+}
+
+// fallbackTimestamp returns the timestamp for events of blob without a usable
+// CreationTime, and the fallback used ("" if the event shouldn't be published)
+func (t *tenant) fallbackTimestamp(blob o365api.Content) (time.Time, string) {
+	switch t.global.TimestampFallback {
+	case config.TimestampFallbackContentCreated:
+		if !blob.ContentCreated.IsZero() {
+			return blob.ContentCreated, config.TimestampFallbackContentCreated
+		}
+		// e.g., a notification without contentCreated
+		return time.Now(), config.TimestampFallbackIngestTime
+	case config.TimestampFallbackIngestTime:
+		return time.Now(), config.TimestampFallbackIngestTime
+	}
+	return time.Time{}, ""
+}
+
+// resolveDeadLetter removes a blob's dead letter, if it has one (e.g., once it's
+// published or it's gone from the api)
+func (t *tenant) resolveDeadLetter(blob o365api.Content) {
+	if t.deadLetters == nil {
+		return
+	}
+	l := DeadLetter{Kind: DeadLetterBlob, Tenant: t.config.RegistryNamespace, ContentID: blob.ContentID}
+	if err := t.deadLetters.remove(l); err != nil {
+		logp.Err("error writing dead-letter store %v: %v", t.deadLetters.path, err)
+	}
+}
+
+// deadLetter keeps letters in the dead-letter store, or logs them if there's no
+// store (e.g., backfills).  if the store can't be written, the error is
+// returned, so callers can keep the cursor from moving past what they came from.
+func (t *tenant) deadLetter(letters ...DeadLetter) error {
+	if t.deadLetters == nil {
+		for _, l := range letters {
+			data, _ := json.Marshal(l)
+			logp.Err("dead letter (%v): %s", l.Reason, data)
+		}
+		return nil
+	}
+	if err := t.deadLetters.add(letters...); err != nil {
+		err = fmt.Errorf("error writing dead-letter store %v: %v", t.deadLetters.path, err)
+		logp.Error(err)
+		return err
+	}
+	for _, l := range letters {
+		logp.Warn("dead-lettered %v (%v blob %v), see %v: %v", l.Kind, l.ContentType, l.ContentID, t.deadLetters.path, l.Reason)
+	}
+	return nil
+}
+
+// blobResult holds a downloaded content blob (or the error encountered getting it)
+type blobResult struct {
+	location o365api.Content // entry from listAllAvailableContent
+	content  []common.MapStr
+	err      error
+}
+
+// downloadContent gets content blobs using up to t.global.MaxConcurrentDownloads
+// concurrent requests.  it returns a channel of per-blob result channels in the
+// same order as availableContent, so callers can publish (and advance the
+// registry) in contentCreated order no matter which downloads finish first.
+// closing done stops any further downloads from being started.
+func (t *tenant) downloadContent(ctx context.Context, availableContent []o365api.Content, done <-chan struct{}) <-chan chan blobResult {
+	workers := t.global.MaxConcurrentDownloads
+	if workers < 1 {
+		workers = 1
+	}
+	logp.Debug("beat", "downloading %v blob(s) with up to %v concurrent requests", len(availableContent), workers)
+
+	slots := make(chan struct{}, workers)          // bounds concurrent downloads
+	pending := make(chan chan blobResult, workers) // bounds results waiting to be published
+	go func() {
+		defer close(pending)
+		for _, v := range availableContent {
+			select {
+			case slots <- struct{}{}:
+			case <-done:
+				return
+			}
+			result := make(chan blobResult, 1)
+			go func(v o365api.Content) {
+				defer func() { <-slots }()
+				content, err := t.getContent(ctx, v.ContentURI)
+				result <- blobResult{location: v, content: content, err: err}
+			}(v)
+			select {
+			case pending <- result:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return pending
+}
+
+// poll gets and publishes all of the tenant's content created since each content type's registry cursor
+func (t *tenant) poll(ctx context.Context) error {
+	// start each content type's span at its last contentCreated (less the overlap
+	// window) or max t.global.ContentMaxAge (default 7 days)
+	now := time.Now()
+	starts := map[string]time.Time{}
+	for _, contentType := range t.config.ContentTypes {
+		start := now.Add(-t.global.ContentMaxAge)
+		if last := t.registrar.start(t.config.RegistryNamespace, contentType); start.Before(last) {
+			start = last
+		}
+		logp.Debug("beat", "polling %v %v since %v", t.config.Name, contentType, start)
+		starts[contentType] = start
+	}
+
+	// get all available content locations (sorted by contentCreated):
+	listed, err := t.listAllAvailableContent(ctx, starts, now)
+	if err != nil {
+		logp.Err("error listing all available content until %v: %v", now, err)
+		return err
+	}
+
+	// skip blobs already published (re-listed in the overlap window or delivered
+	// by webhook) or still waiting to be acknowledged from a previous poll:
+	var availableContent, skipped []o365api.Content
+	for _, blob := range listed {
+		if t.registrar.skip(t.config.RegistryNamespace, blob) {
+			logp.Debug("beat", "skipping processed or in-flight %v blob %v", blob.ContentType, blob.ContentID)
+			skipped = append(skipped, blob)
+			continue
+		}
+		availableContent = append(availableContent, blob)
+	}
+
+	if err := t.process(ctx, availableContent, false); err != nil {
+		return err
+	}
+	// queued after everything above, so cursors only move past blobs published by
+	// webhook once the poll's own (older) blobs are acknowledged
+	for _, blob := range skipped {
+		t.registrar.pass(t.config.RegistryNamespace, blob)
+	}
+	return t.retryDeadLetters(ctx)
+}
+
+// retryDeadLetters gets the tenant's dead-lettered blobs again (marking them
+// processed like webhook content, as cursors have moved past them), dropping
+// those that have expired from the API
+func (t *tenant) retryDeadLetters(ctx context.Context) error {
+	if t.deadLetters == nil {
+		return nil
+	}
+	now := time.Now()
+	var retries []o365api.Content
+	for _, l := range t.deadLetters.list() {
+		if l.Kind != DeadLetterBlob || l.Tenant != t.config.RegistryNamespace || !t.collects(l.ContentType) {
+			continue
+		}
+		if l.expired(now) {
+			logp.Warn("dead-lettered %v blob %v expired from the api after %v attempt(s), dropping it (last error: %v)", l.ContentType, l.ContentID, l.Attempts, l.Reason)
+			t.resolveDeadLetter(l.content())
+			continue
+		}
+		if blob := l.content(); !t.registrar.skip(t.config.RegistryNamespace, blob) {
+			retries = append(retries, blob)
+		}
+	}
+	if len(retries) == 0 {
+		return nil
+	}
+	logp.Info("retrying %v dead-lettered blob(s) for %v", len(retries), t.config.Name)
+	return t.process(ctx, retries, true)
+}
+
+// push gets and publishes content announced to the webhook.  it's marked
+// processed but doesn't move the registry cursors, so anything older that
+// wasn't announced is still picked up by the next poll.
+func (t *tenant) push(ctx context.Context, notified []o365api.Content) error {
+	var availableContent []o365api.Content
+	for _, blob := range notified {
+		if t.registrar.skip(t.config.RegistryNamespace, blob) {
+			logp.Debug("beat", "skipping processed or in-flight %v blob %v", blob.ContentType, blob.ContentID)
+			continue
+		}
+		availableContent = append(availableContent, blob)
+	}
+	logp.Info("getting %v blob(s) announced to webhook for %v", len(availableContent), t.config.Name)
+	return t.process(ctx, availableContent, true)
+}
+
+// process downloads and publishes content blobs, queueing each with the registrar
+func (t *tenant) process(ctx context.Context, availableContent []o365api.Content, pushed bool) error {
+	// get the actual content concurrently, but publish it in order so the registry
+	// never moves past a blob that hasn't been published (and acknowledged) yet:
+	done := make(chan struct{})
+	defer close(done)
+	for pending := range t.downloadContent(ctx, availableContent, done) {
+		result := <-pending
+		if ctx.Err() != nil {
+			// stopping: publish nothing more (this and later blobs are collected after restart)
+			return ctx.Err()
+		}
+		if o365api.IsThrottled(result.err) {
+			// leave this and later blobs for the next poll
+			return result.err
+		}
+		if o365api.IsContentGone(result.err) {
+			logp.Warn("%v blob %v has expired or no longer exists (%v), skipping it", result.location.ContentType, result.location.ContentID, o365api.ErrorCode(result.err))
+			t.resolveDeadLetter(result.location)
+			continue
+		}
+		if result.err != nil {
+			logp.Warn("error getting content: %v, moving to next blob", result.err)
+			if err := t.deadLetter(newBlobDeadLetter(t.config.RegistryNamespace, result.location, result.err)); err != nil {
+				// leave this and later blobs for the next poll rather than losing it
+				return err
+			}
+			continue
+		}
+		events, err := t.timestampEvents(result.location, result.content)
+		if err != nil {
+			// the blob stays out of the registrar, so the next poll collects it again
+			return err
+		}
+		blob := t.registrar.add(t.config.RegistryNamespace, result.location, pushed)
+		location := result.location
+		blob.onAcked = func() { t.resolveDeadLetter(location) }
+		count := t.publish(events, blob)
+		logp.Debug("beat", "published %v blob created %v, awaiting acknowledgement", blob.blob.ContentType, blob.blob.ContentCreated)
+		t.registrar.published(blob, count)
+	}
+	return nil
+}
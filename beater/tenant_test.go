@@ -0,0 +1,222 @@
+// +build !integration
+
+package beater
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+
+	"github.com/counteractive/o365beat/config"
+	"github.com/counteractive/o365beat/o365api"
+)
+
+// testClient records the events published to it
+type testClient struct {
+	mutex  sync.Mutex
+	events []beat.Event
+	closed bool
+}
+
+func (c *testClient) Publish(evt beat.Event) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.events = append(c.events, evt)
+}
+
+func (c *testClient) PublishAll(events []beat.Event) {
+	for _, evt := range events {
+		c.Publish(evt)
+	}
+}
+
+func (c *testClient) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.closed = true
+	return nil
+}
+
+// published returns the content ids of the blobs published, in order
+func (c *testClient) published() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var ids []string
+	for _, evt := range c.events {
+		id := evt.Private.(*pendingBlob).blob.ContentID
+		if len(ids) == 0 || ids[len(ids)-1] != id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// privates returns the Private fields of the blob's published events, as
+// ackEvents receives them
+func (c *testClient) privates(contentID string) []interface{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var privates []interface{}
+	for _, evt := range c.events {
+		if p := evt.Private.(*pendingBlob); p.blob.ContentID == contentID {
+			privates = append(privates, p)
+		}
+	}
+	return privates
+}
+
+// contentServer serves an api whose blobs (named by content id) each hold two
+// events (unless they're in bodies) and are delayed by delays, or fail with the
+// status in failures.  it records the order downloads finish in.  its
+// Audit.General subscription is enabled, and listing it returns listed.
+type contentServer struct {
+	*httptest.Server
+	delays   map[string]time.Duration
+	failures map[string]int
+	bodies   map[string]string
+	listed   []o365api.Content
+	mutex    sync.Mutex
+	finished []string
+}
+
+func newContentServer(delays map[string]time.Duration, failures map[string]int) *contentServer {
+	s := &contentServer{delays: delays, failures: failures}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/oauth2/token") {
+			fmt.Fprintf(w, `{"token_type":"Bearer","access_token":"x","expires_on":"%d"}`, time.Now().Add(time.Hour).Unix())
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/subscriptions/list") {
+			fmt.Fprint(w, `[{"contentType":"Audit.General","status":"enabled"}]`)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/subscriptions/content") {
+			// each listing covers a span of up to a day
+			start, _ := time.Parse("2006-01-02T15:04:05", r.URL.Query().Get("startTime"))
+			end, _ := time.Parse("2006-01-02T15:04:05", r.URL.Query().Get("endTime"))
+			listed := []o365api.Content{}
+			for _, blob := range s.listed {
+				if !blob.ContentCreated.Before(start) && blob.ContentCreated.Before(end) {
+					listed = append(listed, blob)
+				}
+			}
+			json.NewEncoder(w).Encode(listed)
+			return
+		}
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		time.Sleep(s.delays[id])
+		s.mutex.Lock()
+		s.finished = append(s.finished, id)
+		s.mutex.Unlock()
+		if status := s.failures[id]; status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		if body, ok := s.bodies[id]; ok {
+			fmt.Fprint(w, body)
+			return
+		}
+		fmt.Fprintf(w, `[{"Id":"%v-1","CreationTime":"2020-01-02T03:04:05"},{"Id":"%v-2","CreationTime":"2020-01-02T03:04:06"}]`, id, id)
+	}))
+	return s
+}
+
+// blobs returns the server's content blobs, created a second apart
+func (s *contentServer) blobs(ids ...string) []o365api.Content {
+	var blobs []o365api.Content
+	for i, id := range ids {
+		blob := testBlob(id, time.Duration(i)*time.Second)
+		blob.ContentURI = s.URL + "/api/v1.0/d/activity/feed/audit/" + id
+		blobs = append(blobs, blob)
+	}
+	return blobs
+}
+
+// newTestTenant returns a tenant collecting from s, downloading up to
+// workers blobs at a time
+func newTestTenant(t *testing.T, s *contentServer, workers int) (*tenant, *testClient) {
+	api, err := o365api.NewClient(o365api.Config{
+		AuthMode:     o365api.AuthModeSecret,
+		ClientSecret: "secret",
+		TenantDomain: "contoso.onmicrosoft.com",
+		DirectoryID:  "d",
+		LoginURL:     s.URL,
+		ResourceURL:  s.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, _ := testRegistrar()
+	client := &testClient{}
+	global := config.DefaultConfig
+	global.MaxConcurrentDownloads = workers
+	return &tenant{
+		config:    config.TenantConfig{Name: "contoso", DirectoryID: "d", RegistryNamespace: "t", ContentTypes: []string{"Audit.General"}},
+		global:    global,
+		api:       api,
+		client:    client,
+		registrar: r,
+	}, client
+}
+
+func TestProcessPublishesInOrder(t *testing.T) {
+	// a finishes downloading last, but is published first
+	s := newContentServer(map[string]time.Duration{"a": 200 * time.Millisecond, "b": 100 * time.Millisecond}, nil)
+	defer s.Close()
+	tn, client := newTestTenant(t, s, 3)
+
+	if err := tn.process(context.Background(), s.blobs("a", "b", "c"), false); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(s.finished, ""); got != "cba" {
+		t.Errorf("downloads finished in order %v, want cba (concurrently)", got)
+	}
+	if got := strings.Join(client.published(), ""); got != "abc" {
+		t.Errorf("published blobs in order %v, want abc", got)
+	}
+
+	cursor := func() string {
+		return tn.registrar.reg.cursor("t", "Audit.General").ContentID
+	}
+	tn.registrar.ackEvents(client.privates("c"))
+	tn.registrar.ackEvents(client.privates("b"))
+	if got := cursor(); got != "" {
+		t.Fatalf("cursor moved to %v before a was acknowledged", got)
+	}
+	tn.registrar.ackEvents(client.privates("a")[:1])
+	if got := cursor(); got != "" {
+		t.Fatalf("cursor moved to %v before all of a was acknowledged", got)
+	}
+	tn.registrar.ackEvents(client.privates("a")[1:])
+	if got := cursor(); got != "c" {
+		t.Fatalf("got cursor %q, want c once everything was acknowledged", got)
+	}
+}
+
+func TestProcessStopsWhenThrottled(t *testing.T) {
+	// c and d finish downloading before b is throttled
+	s := newContentServer(map[string]time.Duration{"b": 100 * time.Millisecond}, map[string]int{"b": http.StatusTooManyRequests})
+	defer s.Close()
+	tn, client := newTestTenant(t, s, 4)
+
+	err := tn.process(context.Background(), s.blobs("a", "b", "c", "d"), false)
+	if !o365api.IsThrottled(err) {
+		t.Fatalf("got %v, want the throttling error", err)
+	}
+	if got := strings.Join(client.published(), ""); got != "a" {
+		t.Errorf("published %v, want only a", got)
+	}
+	for _, blob := range s.blobs("a", "b", "c", "d") {
+		if queued := tn.registrar.skip("t", blob); queued != (blob.ContentID == "a") {
+			t.Errorf("blob %v queued: %v", blob.ContentID, queued)
+		}
+	}
+}
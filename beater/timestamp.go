@@ -0,0 +1,37 @@
+package beater
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// creationTimeLayouts are the CreationTime formats seen from the API, tried in
+// order.  usually there's no offset (it's UTC), but some events have a "Z" or
+// an offset; fractional seconds are accepted by all of them when parsing.
+var creationTimeLayouts = []string{
+	"2006-01-02T15:04:05", // the documented format, UTC
+	time.RFC3339Nano,      // with "Z" or an offset
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+}
+
+// parseCreationTime parses an event's CreationTime, which may be missing or
+// not a string
+func parseCreationTime(v interface{}) (time.Time, error) {
+	s, ok := v.(string)
+	if !ok {
+		if v == nil {
+			return time.Time{}, fmt.Errorf("CreationTime is missing")
+		}
+		return time.Time{}, fmt.Errorf("CreationTime is a %T, not a string", v)
+	}
+	s = strings.TrimSpace(s)
+	for _, layout := range creationTimeLayouts {
+		if ts, err := time.Parse(layout, s); err == nil {
+			return ts.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("CreationTime %q isn't in a known format", s)
+}
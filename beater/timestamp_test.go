@@ -0,0 +1,78 @@
+// +build !integration
+
+package beater
+
+import (
+	"testing"
+	"time"
+
+	"github.com/counteractive/o365beat/config"
+	"github.com/counteractive/o365beat/o365api"
+)
+
+func TestParseCreationTime(t *testing.T) {
+	want := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		creationTime interface{}
+		want         time.Time
+	}{
+		{"2020-01-02T03:04:05", want},
+		{"2020-01-02T03:04:05.678", want.Add(678 * time.Millisecond)},
+		{"2020-01-02T03:04:05Z", want},
+		{"2020-01-02T03:04:05.1234567Z", want.Add(123456700)},
+		{"2020-01-02T04:04:05+01:00", want},
+		{"2020-01-02T04:04:05+0100", want},
+		{"2020-01-02 03:04:05", want},
+		{"2020-01-02 04:04:05+01:00", want},
+		{" 2020-01-02T03:04:05 ", want},
+	}
+	for _, test := range tests {
+		got, err := parseCreationTime(test.creationTime)
+		if err != nil || !got.Equal(test.want) || got.Location() != time.UTC {
+			t.Errorf("%q: got %v, %v, want %v", test.creationTime, got, err, test.want)
+		}
+	}
+
+	for _, creationTime := range []interface{}{nil, "", "yesterday", "02/01/2020 03:04:05", float64(1577934245)} {
+		if got, err := parseCreationTime(creationTime); err == nil {
+			t.Errorf("%v: got %v, want an error", creationTime, got)
+		}
+	}
+}
+
+func TestFallbackTimestamp(t *testing.T) {
+	contentCreated := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	blob := o365api.Content{ContentCreated: contentCreated}
+	tests := []struct {
+		fallback string
+		blob     o365api.Content
+		want     string // the fallback used
+	}{
+		{config.TimestampFallbackContentCreated, blob, config.TimestampFallbackContentCreated},
+		{config.TimestampFallbackContentCreated, o365api.Content{}, config.TimestampFallbackIngestTime},
+		{config.TimestampFallbackIngestTime, blob, config.TimestampFallbackIngestTime},
+		{config.TimestampFallbackNone, blob, ""},
+	}
+	for _, test := range tests {
+		tn := &tenant{global: config.Config{TimestampFallback: test.fallback}}
+		before := time.Now()
+		ts, used := tn.fallbackTimestamp(test.blob)
+		if used != test.want {
+			t.Errorf("%v: used %q, want %q", test.fallback, used, test.want)
+		}
+		switch used {
+		case config.TimestampFallbackContentCreated:
+			if !ts.Equal(contentCreated) {
+				t.Errorf("%v: got %v, want the blob's contentCreated", test.fallback, ts)
+			}
+		case config.TimestampFallbackIngestTime:
+			if ts.Before(before) || ts.After(time.Now()) {
+				t.Errorf("%v: got %v, want the current time", test.fallback, ts)
+			}
+		default:
+			if !ts.IsZero() {
+				t.Errorf("%v: got %v, want none", test.fallback, ts)
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package beater
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+
+	"github.com/counteractive/o365beat/config"
+	"github.com/counteractive/o365beat/o365api"
+)
+
+// maxNotificationSize caps the body of a webhook request
+const maxNotificationSize = 10 << 20
+
+// webhookServer receives content notifications pushed by the API and hands them
+// to the tenant they belong to (see config.WebhookConfig)
+type webhookServer struct {
+	config  config.WebhookConfig
+	tenants map[string]*tenant // keyed by directory id (the notification's tenantId)
+	server  *http.Server
+}
+
+func newWebhookServer(c config.WebhookConfig, tenants []*tenant) *webhookServer {
+	s := &webhookServer{config: c, tenants: map[string]*tenant{}}
+	for _, t := range tenants {
+		if _, ok := s.tenants[t.config.DirectoryID]; !ok {
+			s.tenants[t.config.DirectoryID] = t
+		}
+	}
+	s.server = &http.Server{
+		Addr:         c.Listen,
+		Handler:      s,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+	return s
+}
+
+// start listens (before subscriptions are started, since the API validates the
+// webhook then) and serves in the background until stop
+func (s *webhookServer) start() error {
+	ln, err := net.Listen("tcp", s.config.Listen)
+	if err != nil {
+		logp.Error(err)
+		return err
+	}
+	logp.Info("listening for webhook notifications on %v (registered as %v)", ln.Addr(), s.config.Address)
+	go func() {
+		var err error
+		if s.config.Certificate != "" {
+			err = s.server.ServeTLS(ln, s.config.Certificate, s.config.Key)
+		} else {
+			logp.Warn("webhook listener has no certificate, serving plain http (terminate tls in front of it)")
+			err = s.server.Serve(ln)
+		}
+		if err != nil && err != http.ErrServerClosed {
+			logp.Err("webhook listener stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (s *webhookServer) stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		logp.Warn("error stopping webhook listener: %v", err)
+	}
+}
+
+// ServeHTTP answers the API's validation request and queues announced content
+// with its tenant.  it responds right away, since the API expects a quick reply
+// and disables webhooks that keep failing; polling catches up on anything dropped.
+func (s *webhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	authID := r.Header.Get(o365api.WebhookAuthIDHeader)
+	if subtle.ConstantTimeCompare([]byte(authID), []byte(s.config.AuthID)) != 1 {
+		logp.Warn("rejecting webhook request from %v with missing or wrong %v", r.RemoteAddr, o365api.WebhookAuthIDHeader)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxNotificationSize))
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+
+	// notifications are an array, the validation request is an object
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '{' {
+		var validation o365api.WebhookValidation
+		if err := json.Unmarshal(body, &validation); err != nil || validation.ValidationCode == "" {
+			http.Error(w, "expected validationCode", http.StatusBadRequest)
+			return
+		}
+		logp.Info("webhook validated by the api")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var notifications []o365api.Notification
+	if err := json.Unmarshal(body, &notifications); err != nil {
+		logp.Warn("error decoding webhook notifications: %v", err)
+		http.Error(w, "error decoding notifications", http.StatusBadRequest)
+		return
+	}
+	if err := s.check(notifications); err != nil {
+		logp.Warn("rejecting webhook notifications from %v: %v", r.RemoteAddr, err)
+		http.Error(w, "invalid notifications", http.StatusBadRequest)
+		return
+	}
+	s.dispatch(notifications)
+	w.WriteHeader(http.StatusOK)
+}
+
+// check rejects notifications for tenants the beat doesn't collect, or whose
+// contentUri isn't on their tenant's api (it would be sent the tenant's token)
+func (s *webhookServer) check(notifications []o365api.Notification) error {
+	for _, n := range notifications {
+		t, ok := s.tenants[n.TenantID]
+		if !ok {
+			return fmt.Errorf("unknown tenant %q", n.TenantID)
+		}
+		if err := t.api.CheckContentURI(n.ContentURI); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dispatch groups (checked) notifications by tenant and queues them without blocking
+func (s *webhookServer) dispatch(notifications []o365api.Notification) {
+	byTenant := map[*tenant][]o365api.Content{}
+	for _, n := range notifications {
+		t := s.tenants[n.TenantID] // checked by check
+		if !t.collects(n.ContentType) {
+			logp.Debug("beat", "ignoring webhook notification for unconfigured content type %v", n.ContentType)
+			continue
+		}
+		byTenant[t] = append(byTenant[t], n.Content)
+	}
+	for t, content := range byTenant {
+		select {
+		case t.notifications <- content:
+			logp.Debug("beat", "queued %v blob(s) announced to webhook for %v", len(content), t.config.Name)
+		default:
+			logp.Warn("too many webhook notifications waiting for %v, dropping %v blob(s) until the next poll", t.config.Name, len(content))
+		}
+	}
+}
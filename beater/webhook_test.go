@@ -0,0 +1,83 @@
+// +build !integration
+
+package beater
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/counteractive/o365beat/config"
+	"github.com/counteractive/o365beat/o365api"
+)
+
+func newTestWebhook(t *testing.T) (*webhookServer, *tenant) {
+	api, err := o365api.NewClient(o365api.Config{
+		AuthMode:     o365api.AuthModeSecret,
+		ClientSecret: "secret",
+		DirectoryID:  "d",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tn := &tenant{
+		config:        config.TenantConfig{DirectoryID: "d", ContentTypes: []string{"Audit.General"}},
+		api:           api,
+		notifications: make(chan []o365api.Content, 1),
+	}
+	return newWebhookServer(config.WebhookConfig{AuthID: "sec"}, []*tenant{tn}), tn
+}
+
+func postWebhook(s *webhookServer, authID, body string) int {
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	req.Header.Set(o365api.WebhookAuthIDHeader, authID)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestWebhookAuthID(t *testing.T) {
+	s, _ := newTestWebhook(t)
+	for authID, want := range map[string]int{"": 401, "wrong": 401, "sec": 200} {
+		if code := postWebhook(s, authID, `{"validationCode":"x"}`); code != want {
+			t.Errorf("auth id %q: got %v, want %v", authID, code, want)
+		}
+	}
+}
+
+func TestWebhookNotifications(t *testing.T) {
+	s, tn := newTestWebhook(t)
+	uri := "https://manage.office.com/api/v1.0/d/activity/feed/audit/1"
+	body := `[{"tenantId":"d","contentType":"Audit.General","contentId":"1","contentUri":"` + uri + `","contentCreated":"2015-05-23T17:35:00.000Z"},
+		{"tenantId":"d","contentType":"Audit.Exchange","contentId":"2","contentUri":"` + uri + `"}]`
+	if code := postWebhook(s, "sec", body); code != 200 {
+		t.Fatalf("got %v", code)
+	}
+	got := <-tn.notifications
+	if len(got) != 1 || got[0].ContentID != "1" || got[0].ContentURI != uri || got[0].ContentCreated.IsZero() {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestWebhookRejectsNotifications(t *testing.T) {
+	s, tn := newTestWebhook(t)
+	tests := map[string]string{
+		"unknown tenant":   `[{"tenantId":"other","contentType":"Audit.General","contentUri":"https://manage.office.com/api/v1.0/other/activity/feed/audit/1"}]`,
+		"other host":       `[{"tenantId":"d","contentType":"Audit.General","contentUri":"https://attacker.example.com/api/v1.0/d/activity/feed/audit/1"}]`,
+		"plain http":       `[{"tenantId":"d","contentType":"Audit.General","contentUri":"http://manage.office.com/api/v1.0/d/activity/feed/audit/1"}]`,
+		"other tenant's":   `[{"tenantId":"d","contentType":"Audit.General","contentUri":"https://manage.office.com/api/v1.0/other/activity/feed/audit/1"}]`,
+		"one bad of many":  `[{"tenantId":"d","contentType":"Audit.General","contentUri":"https://manage.office.com/api/v1.0/d/activity/feed/audit/1"},{"tenantId":"d","contentType":"Audit.General","contentUri":"https://attacker.example.com/"}]`,
+		"missing uri":      `[{"tenantId":"d","contentType":"Audit.General"}]`,
+		"userinfo in host": `[{"tenantId":"d","contentType":"Audit.General","contentUri":"https://manage.office.com@attacker.example.com/api/v1.0/d/activity/feed/audit/1"}]`,
+	}
+	for name, body := range tests {
+		if code := postWebhook(s, "sec", body); code != 400 {
+			t.Errorf("%v: got %v, want 400", name, code)
+		}
+	}
+	select {
+	case got := <-tn.notifications:
+		t.Fatalf("rejected notifications were queued: %v", got)
+	default:
+	}
+}
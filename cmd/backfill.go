@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/libbeat/cmd/instance"
+
+	"github.com/counteractive/o365beat/beater"
+)
+
+// timeFormats are accepted for --start and --end (UTC unless an offset is given)
+var timeFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+func parseTime(value string) (time.Time, error) {
+	for _, layout := range timeFormats {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("can't parse %q as a time (use RFC3339, e.g. 2020-01-02T15:04:05Z, or 2020-01-02)", value)
+}
+
+func genBackfillCmd() *cobra.Command {
+	var start, end string
+	var options beater.BackfillOptions
+	backfillCmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Collect content from a past time window (up to 7 days ago) without updating the registry",
+		Long: `Collect content created between --start and --end (default now) and publish
+it through the configured output, then exit once all of it is acknowledged.
+The API keeps content for 7 days, so earlier starts are clamped.  The registry
+file is not read or updated, so the regular beat is unaffected.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if options.Start, err = parseTime(start); err != nil {
+				return fmt.Errorf("--start: %v", err)
+			}
+			if end != "" {
+				if options.End, err = parseTime(end); err != nil {
+					return fmt.Errorf("--end: %v", err)
+				}
+			}
+			return instance.Run(settings, beater.NewBackfill(options))
+		},
+	}
+	backfillCmd.Flags().StringVar(&start, "start", "", "start of the window (required)")
+	backfillCmd.Flags().StringVar(&end, "end", "", "end of the window (default now)")
+	backfillCmd.Flags().StringSliceVar(&options.ContentTypes, "content-type", nil, "content type to collect, repeatable (default all configured)")
+	backfillCmd.Flags().StringSliceVar(&options.Tenants, "tenant", nil, "tenant name to collect, repeatable (default all configured)")
+	backfillCmd.MarkFlagRequired("start")
+	return backfillCmd
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/libbeat/cmd/instance"
+
+	"github.com/counteractive/o365beat/beater"
+)
+
+func genDeadLettersCmd() *cobra.Command {
+	deadLettersCmd := &cobra.Command{
+		Use:   "dead-letters",
+		Short: "List and replay blobs and events that couldn't be published (see dead_letter_path)",
+	}
+	deadLettersCmd.AddCommand(genDeadLettersListCmd())
+	deadLettersCmd.AddCommand(genDeadLettersReplayCmd())
+	return deadLettersCmd
+}
+
+func genDeadLettersListCmd() *cobra.Command {
+	var asJSON bool
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List dead letters, oldest content first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := instance.NewInitializedBeat(settings)
+			if err != nil {
+				return fmt.Errorf("error initializing beat: %v", err)
+			}
+			letters, err := beater.ListDeadLetters(b.BeatConfig)
+			if err != nil {
+				return err
+			}
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(letters)
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAMESPACE\tKIND\tCONTENT TYPE\tCONTENT CREATED\tCONTENT ID\tATTEMPTS\tLAST FAILED\tREASON")
+			for _, l := range letters {
+				fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", l.Tenant, l.Kind, l.ContentType,
+					l.ContentCreated.Format(time.RFC3339), orDash(l.ContentID), l.Attempts,
+					l.LastFailed.Format(time.RFC3339), l.Reason)
+			}
+			return w.Flush()
+		},
+	}
+	listCmd.Flags().BoolVar(&asJSON, "json", false, "write the dead letters as json, including events and payloads")
+	return listCmd
+}
+
+func genDeadLettersReplayCmd() *cobra.Command {
+	var options beater.ReplayOptions
+	replayCmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Publish dead letters again, removing those acknowledged (the beat must not be running)",
+		Long: `Download dead-lettered blobs again and publish their events, and publish
+dead-lettered events with their timestamp_fallback timestamp (their blob's
+contentCreated if timestamp_fallback is none), through the configured output.
+Dead letters are removed once acknowledged; those that fail again are kept.
+The registry is not updated.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return instance.Run(settings, beater.NewReplay(options))
+		},
+	}
+	replayCmd.Flags().StringSliceVar(&options.ContentTypes, "content-type", nil, "content type to replay, repeatable (default all)")
+	replayCmd.Flags().StringSliceVar(&options.Tenants, "tenant", nil, "tenant name to replay, repeatable (default all configured)")
+	return replayCmd
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/libbeat/cmd/instance"
+
+	"github.com/counteractive/o365beat/beater"
+)
+
+// openRegistry loads the config and opens the registry (locked if writable)
+func openRegistry(writable bool) (*beater.RegistryEditor, error) {
+	b, err := instance.NewInitializedBeat(settings)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing beat: %v", err)
+	}
+	return beater.OpenRegistry(b.BeatConfig, writable)
+}
+
+func genRegistryCmd() *cobra.Command {
+	registryCmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Inspect and edit the registry (the beat must not be running to edit it)",
+	}
+	registryCmd.AddCommand(genRegistryShowCmd())
+	registryCmd.AddCommand(genRegistrySetCmd())
+	registryCmd.AddCommand(genRegistryResetCmd())
+	registryCmd.AddCommand(genRegistryExportCmd())
+	registryCmd.AddCommand(genRegistryImportCmd())
+	return registryCmd
+}
+
+func genRegistryShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Show the registry's cursor for each tenant and content type",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			editor, err := openRegistry(false)
+			if err != nil {
+				return err
+			}
+			defer editor.Close()
+			cursors, err := editor.Cursors()
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TENANT\tNAMESPACE\tCONTENT TYPE\tCONTENT CREATED\tCONTENT ID\tPROCESSED")
+			for _, c := range cursors {
+				fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", orDash(c.Tenant), c.Namespace, c.ContentType,
+					c.ContentCreated.Format(time.RFC3339), orDash(c.ContentID), c.Processed)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func genRegistrySetCmd() *cobra.Command {
+	var at string
+	var tenants, contentTypes []string
+	setCmd := &cobra.Command{
+		Use:   "set",
+		Short: "Move cursors so collection resumes after --time (within content_max_age)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t, err := parseTime(at)
+			if err != nil {
+				return fmt.Errorf("--time: %v", err)
+			}
+			editor, err := openRegistry(true)
+			if err != nil {
+				return err
+			}
+			defer editor.Close()
+			return editor.Set(tenants, contentTypes, t)
+		},
+	}
+	setCmd.Flags().StringVar(&at, "time", "", "content created up to this time is considered processed (required)")
+	setCmd.Flags().StringSliceVar(&tenants, "tenant", nil, "tenant name, repeatable (default all configured)")
+	setCmd.Flags().StringSliceVar(&contentTypes, "content-type", nil, "content type, repeatable (default all configured)")
+	setCmd.MarkFlagRequired("time")
+	return setCmd
+}
+
+func genRegistryResetCmd() *cobra.Command {
+	var tenants, contentTypes []string
+	resetCmd := &cobra.Command{
+		Use:   "reset",
+		Short: "Remove cursors so collection starts over from content_max_age ago (default all)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			editor, err := openRegistry(true)
+			if err != nil {
+				return err
+			}
+			defer editor.Close()
+			return editor.Reset(tenants, contentTypes)
+		},
+	}
+	resetCmd.Flags().StringSliceVar(&tenants, "tenant", nil, "tenant name, repeatable")
+	resetCmd.Flags().StringSliceVar(&contentTypes, "content-type", nil, "content type, repeatable")
+	return resetCmd
+}
+
+func genRegistryExportCmd() *cobra.Command {
+	var file string
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write the registry as json to stdout (or --file)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			editor, err := openRegistry(false)
+			if err != nil {
+				return err
+			}
+			defer editor.Close()
+			var w io.Writer = os.Stdout
+			if file != "" {
+				f, err := os.Create(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+			return editor.Export(w)
+		},
+	}
+	exportCmd.Flags().StringVar(&file, "file", "", "file to write (default stdout)")
+	return exportCmd
+}
+
+func genRegistryImportCmd() *cobra.Command {
+	var file string
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Replace the registry with one read from stdin (or --file)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var r io.Reader = os.Stdin
+			if file != "" {
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				r = f
+			}
+			editor, err := openRegistry(true)
+			if err != nil {
+				return err
+			}
+			defer editor.Close()
+			return editor.Import(r)
+		},
+	}
+	importCmd.Flags().StringVar(&file, "file", "", "file to read (default stdin)")
+	return importCmd
+}
@@ -10,5 +10,14 @@ import (
 var name = "o365beat"
 var version = "1.5.1" // TODO consider moving this or pulling from conf or env
 
+var settings = instance.Settings{Name: name, Version: version}
+
 // RootCmd to handle beats cli
-var RootCmd = cmd.GenRootCmdWithSettings(beater.New, instance.Settings{Name: name, Version: version})
+var RootCmd = cmd.GenRootCmdWithSettings(beater.New, settings)
+
+func init() {
+	RootCmd.AddCommand(genBackfillCmd())
+	RootCmd.AddCommand(genSubscriptionsCmd())
+	RootCmd.AddCommand(genRegistryCmd())
+	RootCmd.AddCommand(genDeadLettersCmd())
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/libbeat/cmd/instance"
+
+	"github.com/counteractive/o365beat/beater"
+	"github.com/counteractive/o365beat/o365api"
+)
+
+// subscriptionRow is one tenant's subscription to a content type, as printed
+type subscriptionRow struct {
+	Tenant string `json:"tenant"`
+	o365api.Subscription
+}
+
+// subscriptionsFlags are shared by the subscriptions subcommands
+type subscriptionsFlags struct {
+	tenants []string
+	output  string
+}
+
+func genSubscriptionsCmd() *cobra.Command {
+	flags := &subscriptionsFlags{}
+	subscriptionsCmd := &cobra.Command{
+		Use:   "subscriptions",
+		Short: "Manage content type subscriptions with the Management Activity API",
+	}
+	subscriptionsCmd.PersistentFlags().StringSliceVar(&flags.tenants, "tenant", nil, "tenant name, repeatable (default all configured)")
+	subscriptionsCmd.PersistentFlags().StringVarP(&flags.output, "output", "o", "table", "output format (table or json)")
+
+	subscriptionsCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List all subscriptions (configured or not)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return flags.run(func(ctx context.Context, t beater.TenantAPI) ([]o365api.Subscription, error) {
+				return t.Client.ListSubscriptions(ctx)
+			})
+		},
+	})
+	subscriptionsCmd.AddCommand(&cobra.Command{
+		Use:   "status [content-type...]",
+		Short: "Show the status of subscriptions (default configured content types)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return flags.run(func(ctx context.Context, t beater.TenantAPI) ([]o365api.Subscription, error) {
+				subscriptions, err := t.Client.ListSubscriptions(ctx)
+				if err != nil {
+					return nil, err
+				}
+				var status []o365api.Subscription
+				for _, contentType := range contentTypesOrConfigured(args, t) {
+					sub := o365api.Subscription{ContentType: contentType, Status: "none"}
+					for _, s := range subscriptions {
+						if s.ContentType == contentType {
+							sub = s
+							break
+						}
+					}
+					status = append(status, sub)
+				}
+				return status, nil
+			})
+		},
+	})
+	subscriptionsCmd.AddCommand(&cobra.Command{
+		Use:   "start [content-type...]",
+		Short: "Start subscriptions (default configured content types), with the webhook if enabled",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return flags.run(func(ctx context.Context, t beater.TenantAPI) ([]o365api.Subscription, error) {
+				var started []o365api.Subscription
+				for _, contentType := range contentTypesOrConfigured(args, t) {
+					sub, err := t.Client.StartSubscription(ctx, contentType, t.Webhook)
+					if err != nil {
+						return started, err
+					}
+					started = append(started, *sub)
+				}
+				return started, nil
+			})
+		},
+	})
+	subscriptionsCmd.AddCommand(&cobra.Command{
+		Use:   "stop content-type...",
+		Short: "Stop subscriptions (their content is no longer available once stopped)",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return flags.run(func(ctx context.Context, t beater.TenantAPI) ([]o365api.Subscription, error) {
+				var stopped []o365api.Subscription
+				for _, contentType := range args {
+					if err := t.Client.StopSubscription(ctx, contentType); err != nil {
+						return stopped, err
+					}
+					stopped = append(stopped, o365api.Subscription{ContentType: contentType, Status: "disabled"})
+				}
+				return stopped, nil
+			})
+		},
+	})
+	return subscriptionsCmd
+}
+
+func contentTypesOrConfigured(args []string, t beater.TenantAPI) []string {
+	if len(args) > 0 {
+		return args
+	}
+	return t.ContentTypes
+}
+
+// run loads the config, calls f for each selected tenant and prints the
+// subscriptions it returns (printing what succeeded before any error)
+func (flags *subscriptionsFlags) run(f func(context.Context, beater.TenantAPI) ([]o365api.Subscription, error)) error {
+	if flags.output != "table" && flags.output != "json" {
+		return fmt.Errorf("unknown output format %q (use table or json)", flags.output)
+	}
+	b, err := instance.NewInitializedBeat(settings)
+	if err != nil {
+		return fmt.Errorf("error initializing beat: %v", err)
+	}
+	tenants, err := beater.NewTenantAPIs(b.BeatConfig, flags.tenants)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var rows []subscriptionRow
+	for _, t := range tenants {
+		var subscriptions []o365api.Subscription
+		subscriptions, err = f(ctx, t)
+		for _, sub := range subscriptions {
+			rows = append(rows, subscriptionRow{Tenant: t.Name, Subscription: sub})
+		}
+		if err != nil {
+			err = fmt.Errorf("tenant %v: %v", t.Name, err)
+			break
+		}
+	}
+	if printErr := printSubscriptions(rows, flags.output); printErr != nil {
+		return printErr
+	}
+	return err
+}
+
+func printSubscriptions(rows []subscriptionRow, output string) error {
+	if output == "json" {
+		if rows == nil {
+			rows = []subscriptionRow{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TENANT\tCONTENT TYPE\tSTATUS\tWEBHOOK STATUS\tWEBHOOK ADDRESS\tWEBHOOK EXPIRATION")
+	for _, r := range rows {
+		webhook := o365api.Webhook{}
+		if r.Webhook != nil {
+			webhook = *r.Webhook
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", r.Tenant, r.ContentType, r.Status,
+			orDash(webhook.Status), orDash(webhook.Address), orDash(webhook.Expiration))
+	}
+	return w.Flush()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -3,36 +3,402 @@
 
 package config
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/counteractive/o365beat/o365api"
+)
 
 // Config represents o356beat configuration options
 type Config struct {
-	Period           time.Duration `config:"period"`
-	TenantDomain     string        `config:"tenant_domain"`
-	ClientSecret     string        `config:"client_secret"`
-	CertificatePath  string        `config:"certificate_path"`
-	CertificatePwd   string        `config:"certificate_pwd"` //password for extracting the private key from the certificate
-	ClientID         string        `config:"client_id"`    // aka application id
-	DirectoryID      string        `config:"directory_id"` // aka tenant id
-	ContentTypes     []string      `config:"content_types"`
-	RegistryFilePath string        `config:"registry_file_path"`
-	APITimeout       time.Duration `config:"api_timeout"`
-	ContentMaxAge    time.Duration `config:"content_max_age"`
-	LoginURL         string        `config:"login_url"`
-	ResourceURL      string        `config:"resource_url"`
+	Period                 time.Duration    `config:"period"`
+	TenantDomain           string           `config:"tenant_domain"`
+	ClientSecret           string           `config:"client_secret"`
+	ClientSecretFile       string           `config:"client_secret_file"` // file holding the client secret, reloaded when it changes
+	CertificatePath        string           `config:"certificate_path"`
+	CertificatePwd         string           `config:"certificate_pwd"`       //password for extracting the private key from the certificate
+	CertificateKeyPath     string           `config:"certificate_key_path"`  // PEM private key, if not in the PEM certificate_path file
+	ClientAssertionPath    string           `config:"client_assertion_path"` // pre-signed client assertion (JWT) file
+	Auth                   AuthConfig       `config:"auth"`
+	ClientID               string           `config:"client_id"`    // aka application id
+	DirectoryID            string           `config:"directory_id"` // aka tenant id
+	ContentTypes           []string         `config:"content_types"`
+	RegistryFilePath       string           `config:"registry_file_path"`
+	RegistryOnCorruption   string           `config:"registry_on_corruption"` // fail, rewind or backup (see RegistryOnCorruption* constants)
+	Registry               RegistryConfig   `config:"registry"`
+	TimestampFallback      string           `config:"timestamp_fallback"` // content_created, ingest_time or none (see TimestampFallback* constants)
+	DeadLetterPath         string           `config:"dead_letter_path"`   // where events (and blobs) that can't be published are kept
+	APITimeout             time.Duration    `config:"api_timeout"`
+	ShutdownTimeout        time.Duration    `config:"shutdown_timeout"` // how long Stop waits for polls to end and events to be acknowledged
+	ContentMaxAge          time.Duration    `config:"content_max_age"`
+	ContentOverlap         time.Duration    `config:"content_overlap"` // how far before the registry cursor to re-list content
+	Cloud                  string           `config:"cloud"`           // commercial, gcc, gcc_high, dod or china
+	LoginURL               string           `config:"login_url"`       // overrides the cloud's login url
+	ResourceURL            string           `config:"resource_url"`    // overrides the cloud's resource (api) url
+	MaxConcurrentDownloads int              `config:"max_concurrent_downloads" validate:"min=1"`
+	APIRetry               RetryConfig      `config:"api_retry"`
+	Tenants                []TenantConfig   `config:"tenants"`
+	Webhook                WebhookConfig    `config:"webhook"`
+	TokenCache             TokenCacheConfig `config:"token_cache"`
+	ECS                    ECSConfig        `config:"ecs"`
+	Enums                  EnumsConfig      `config:"enums"`
+	Properties             PropertiesConfig `config:"properties"`
+}
+
+// what to do when the registry file can't be parsed
+const (
+	RegistryOnCorruptionFail   = "fail"   // return an error (the beat doesn't start)
+	RegistryOnCorruptionRewind = "rewind" // start over from content_max_age ago
+	RegistryOnCorruptionBackup = "backup" // use the backup of the previous registry, or fail if there's none
+)
+
+// what to use as an event's timestamp when its CreationTime can't be parsed
+const (
+	TimestampFallbackContentCreated = "content_created" // the blob's contentCreated
+	TimestampFallbackIngestTime     = "ingest_time"     // the time the event is published
+	TimestampFallbackNone           = "none"            // don't publish it, keep it in the dead-letter store
+)
+
+// registry backends (registry.type)
+const (
+	RegistryTypeFile          = "file"          // a json file at registry_file_path
+	RegistryTypeKV            = "kv"            // an embedded key-value database (bbolt) at registry.kv.path
+	RegistryTypeElasticsearch = "elasticsearch" // a document in an elasticsearch index
+)
+
+// RegistryConfig selects where the registry is stored
+type RegistryConfig struct {
+	Type          string                      `config:"type"`
+	Flush         time.Duration               `config:"flush"` // how often acknowledged progress is saved
+	KV            KVRegistryConfig            `config:"kv"`
+	Elasticsearch ElasticsearchRegistryConfig `config:"elasticsearch"`
+}
+
+// KVRegistryConfig configures the kv registry backend
+type KVRegistryConfig struct {
+	Path string `config:"path"`
+}
+
+// ElasticsearchRegistryConfig configures the elasticsearch registry backend
+type ElasticsearchRegistryConfig struct {
+	Hosts      []string      `config:"hosts"` // tried in order, e.g. https://localhost:9200
+	Index      string        `config:"index"`
+	DocumentID string        `config:"document_id"` // use a different id for each beat sharing the index
+	Username   string        `config:"username"`
+	Password   string        `config:"password"`
+	APIKey     string        `config:"api_key"` // id:key, used instead of username and password
+	Timeout    time.Duration `config:"timeout"`
+}
+
+// Validate checks settings that can't be validated with struct tags
+func (c *Config) Validate() error {
+	switch c.Registry.Type {
+	case RegistryTypeFile, RegistryTypeKV:
+	case RegistryTypeElasticsearch:
+		if len(c.Registry.Elasticsearch.Hosts) == 0 {
+			return fmt.Errorf("registry.elasticsearch.hosts is required with registry.type: %v", RegistryTypeElasticsearch)
+		}
+	default:
+		return fmt.Errorf("registry.type must be %v, %v or %v (got %q)",
+			RegistryTypeFile, RegistryTypeKV, RegistryTypeElasticsearch, c.Registry.Type)
+	}
+	if c.Registry.Flush <= 0 {
+		return fmt.Errorf("registry.flush must be positive (got %v)", c.Registry.Flush)
+	}
+	if _, ok := o365api.Clouds[c.Cloud]; !ok && c.Cloud != "" {
+		return fmt.Errorf("cloud must be commercial, gcc, gcc_high, dod or china (got %q)", c.Cloud)
+	}
+	if _, err := c.TenantConfigs(); err != nil {
+		return err
+	}
+	switch c.RegistryOnCorruption {
+	case RegistryOnCorruptionFail, RegistryOnCorruptionRewind, RegistryOnCorruptionBackup:
+	default:
+		return fmt.Errorf("registry_on_corruption must be %v, %v or %v (got %q)",
+			RegistryOnCorruptionFail, RegistryOnCorruptionRewind, RegistryOnCorruptionBackup, c.RegistryOnCorruption)
+	}
+	switch c.TimestampFallback {
+	case TimestampFallbackContentCreated, TimestampFallbackIngestTime, TimestampFallbackNone:
+	default:
+		return fmt.Errorf("timestamp_fallback must be %v, %v or %v (got %q)",
+			TimestampFallbackContentCreated, TimestampFallbackIngestTime, TimestampFallbackNone, c.TimestampFallback)
+	}
+	return nil
+}
+
+// TenantConfig holds the settings for one tenant when collecting from several.
+// content types, period and credentials left empty fall back to the top-level
+// settings (handy for multi-tenant app registrations).
+type TenantConfig struct {
+	Name                string        `config:"name"` // defaults to tenant_domain
+	TenantDomain        string        `config:"tenant_domain"`
+	ClientSecret        string        `config:"client_secret"`
+	ClientSecretFile    string        `config:"client_secret_file"`
+	CertificatePath     string        `config:"certificate_path"`
+	CertificatePwd      string        `config:"certificate_pwd"`
+	CertificateKeyPath  string        `config:"certificate_key_path"`
+	ClientAssertionPath string        `config:"client_assertion_path"`
+	Auth                AuthConfig    `config:"auth"`
+	ClientID            string        `config:"client_id"`
+	DirectoryID         string        `config:"directory_id"`
+	ContentTypes        []string      `config:"content_types"`
+	Period              time.Duration `config:"period"`
+	RegistryNamespace   string        `config:"registry_namespace"` // key for this tenant's registry cursors, defaults to directory_id
+}
+
+// TenantConfigs returns the configured tenants with defaults filled in, or a
+// single tenant built from the top-level settings if no tenants are listed
+func (c *Config) TenantConfigs() ([]TenantConfig, error) {
+	if len(c.Tenants) == 0 {
+		t := c.withTenantDefaults(TenantConfig{})
+		if err := t.resolveAuthMode(); err != nil {
+			return nil, err
+		}
+		return []TenantConfig{t}, nil
+	}
+
+	tenants := make([]TenantConfig, 0, len(c.Tenants))
+	namespaces := map[string]bool{}
+	for i, t := range c.Tenants {
+		if t.DirectoryID == "" || t.TenantDomain == "" {
+			return nil, fmt.Errorf("tenants[%v]: tenant_domain and directory_id are required", i)
+		}
+		t = c.withTenantDefaults(t)
+		if err := t.resolveAuthMode(); err != nil {
+			return nil, fmt.Errorf("tenants[%v] (%v): %v", i, t.Name, err)
+		}
+		if namespaces[t.RegistryNamespace] {
+			return nil, fmt.Errorf("tenants[%v] (%v): registry_namespace %v is used by another tenant", i, t.Name, t.RegistryNamespace)
+		}
+		namespaces[t.RegistryNamespace] = true
+		tenants = append(tenants, t)
+	}
+	return tenants, nil
+}
+
+// withTenantDefaults fills in a tenant's empty settings from the top-level ones
+func (c *Config) withTenantDefaults(t TenantConfig) TenantConfig {
+	if t.TenantDomain == "" && t.DirectoryID == "" {
+		t.TenantDomain, t.DirectoryID = c.TenantDomain, c.DirectoryID
+	}
+	if t.ClientID == "" {
+		t.ClientID = c.ClientID
+	}
+	if t.ClientSecret == "" && t.ClientSecretFile == "" && t.CertificatePath == "" && t.ClientAssertionPath == "" {
+		t.ClientSecret, t.ClientSecretFile = c.ClientSecret, c.ClientSecretFile
+		t.CertificatePath, t.CertificatePwd = c.CertificatePath, c.CertificatePwd
+		t.CertificateKeyPath, t.ClientAssertionPath = c.CertificateKeyPath, c.ClientAssertionPath
+		if t.Auth.Mode == "" {
+			t.Auth = c.Auth
+		}
+	}
+	if len(t.ContentTypes) == 0 {
+		t.ContentTypes = c.ContentTypes
+	}
+	if t.Period <= 0 {
+		t.Period = c.Period
+	}
+	if t.Name == "" {
+		t.Name = t.TenantDomain
+	}
+	if t.RegistryNamespace == "" {
+		t.RegistryNamespace = t.DirectoryID
+	}
+	return t
+}
+
+// credential modes (auth.mode)
+const (
+	AuthModeSecret      = "secret"      // client_secret or client_secret_file
+	AuthModeCertificate = "certificate" // certificate_path (and certificate_key_path, certificate_pwd)
+	AuthModeAssertion   = "assertion"   // client_assertion_path
+)
 
+// AuthConfig selects how to authenticate with the API
+type AuthConfig struct {
+	Mode string `config:"mode"` // inferred from the credentials that are set if empty
+}
+
+// resolveAuthMode checks the tenant's credentials for its auth.mode, or infers
+// the mode if there's exactly one kind of credential set
+func (t *TenantConfig) resolveAuthMode() error {
+	if t.ClientSecret != "" && t.ClientSecretFile != "" {
+		return fmt.Errorf("client_secret and client_secret_file are set: leave only one of them set")
+	}
+	credentials := map[string]string{
+		AuthModeSecret:      t.ClientSecret + t.ClientSecretFile,
+		AuthModeCertificate: t.CertificatePath,
+		AuthModeAssertion:   t.ClientAssertionPath,
+	}
+	settings := map[string]string{
+		AuthModeSecret:      "client_secret",
+		AuthModeCertificate: "certificate_path",
+		AuthModeAssertion:   "client_assertion_path",
+	}
+	if t.ClientSecretFile != "" {
+		settings[AuthModeSecret] = "client_secret_file"
+	}
+
+	if t.Auth.Mode != "" {
+		credential, ok := credentials[t.Auth.Mode]
+		if !ok {
+			return fmt.Errorf("auth.mode must be %v, %v or %v (got %q)", AuthModeSecret, AuthModeCertificate, AuthModeAssertion, t.Auth.Mode)
+		}
+		if credential == "" {
+			return fmt.Errorf("auth.mode is %v, but %v isn't set", t.Auth.Mode, settings[t.Auth.Mode])
+		}
+		return nil
+	}
+
+	var set []string
+	for _, mode := range []string{AuthModeSecret, AuthModeCertificate, AuthModeAssertion} {
+		if credentials[mode] != "" {
+			set = append(set, mode)
+		}
+	}
+	switch len(set) {
+	case 0:
+		return fmt.Errorf("no credentials: set client_secret (or client_secret_file), certificate_path or client_assertion_path")
+	case 1:
+		t.Auth.Mode = set[0]
+		return nil
+	}
+	names := make([]string, len(set))
+	for i, mode := range set {
+		names[i] = settings[mode]
+	}
+	return fmt.Errorf("%v are set: leave only one of them set, or choose one with auth.mode", strings.Join(names, " and "))
+}
+
+// RetryConfig controls how throttled (or otherwise temporarily failing) API
+// requests are retried before the error is returned
+type RetryConfig struct {
+	MaxAttempts    int           `config:"max_attempts" validate:"min=1"` // including the first request
+	InitialBackoff time.Duration `config:"initial_backoff"`
+	MaxBackoff     time.Duration `config:"max_backoff"`
+	MaxElapsed     time.Duration `config:"max_elapsed"` // total time budget for one request and its retries
+}
+
+// WebhookConfig enables push mode: subscriptions are started with a webhook and
+// content announced to it is downloaded right away, with polling every period
+// catching up on anything missed
+type WebhookConfig struct {
+	Enabled     bool   `config:"enabled"`
+	Address     string `config:"address"` // public https url of the listener, registered with the API
+	AuthID      string `config:"auth_id"` // sent back by the API in the Webhook-AuthID header (required)
+	Listen      string `config:"listen"`  // local host:port for the listener
+	Certificate string `config:"certificate"`
+	Key         string `config:"key"` // leave certificate and key empty to serve plain http behind a tls-terminating proxy
+}
+
+// Validate checks the webhook settings when webhook mode is enabled
+func (w *WebhookConfig) Validate() error {
+	if !w.Enabled {
+		return nil
+	}
+	if !strings.HasPrefix(strings.ToLower(w.Address), "https://") {
+		return fmt.Errorf("webhook.address must be an https url (got %q)", w.Address)
+	}
+	if w.AuthID == "" {
+		return fmt.Errorf("webhook.auth_id must be set, so the listener only accepts notifications from the api")
+	}
+	if (w.Certificate == "") != (w.Key == "") {
+		return fmt.Errorf("webhook.certificate and webhook.key must be set together")
+	}
+	return nil
+}
+
+// TokenCacheConfig keeps api tokens in an encrypted file, so restarts can reuse
+// them (see o365api.TokenCache)
+type TokenCacheConfig struct {
+	Path string `config:"path"` // empty disables the cache
+	Key  string `config:"key"`  // passphrase the cache is encrypted with
+}
+
+// Validate checks a key is set when the token cache is enabled
+func (t *TokenCacheConfig) Validate() error {
+	if t.Path != "" && t.Key == "" {
+		return fmt.Errorf("token_cache.key must be set with token_cache.path")
+	}
+	return nil
+}
+
+// ECSConfig controls the Elastic Common Schema fields added to events
+type ECSConfig struct {
+	Enabled bool `config:"enabled"`
+	Mapping int  `config:"mapping"` // mapping version, so upgrades don't change fields until opted in
+}
+
+// ECS mapping versions (ecs.mapping).  the default stays pinned when newer
+// versions are added, so upgrades don't change fields until opted in.
+const (
+	DefaultECSMapping = 1
+	LatestECSMapping  = 1 // the newest version
+)
+
+// EnumsConfig controls the names added for enum fields like RecordType
+type EnumsConfig struct {
+	Enabled bool   `config:"enabled"`
+	Path    string `config:"path"` // json file adding or overriding enum values, for values newer than the beat
+}
 
+// PropertiesConfig controls the decoding of Parameters, ExtendedProperties and
+// ModifiedProperties into o365.parameters, o365.extended_properties and
+// o365.modified_properties.  only the listed names are decoded, so events with
+// arbitrary names can't explode the index mapping; "*" allows every name, and
+// unset lists default to common security-relevant names.
+type PropertiesConfig struct {
+	Enabled            bool     `config:"enabled"`
+	Parameters         []string `config:"parameters"`
+	ExtendedProperties []string `config:"extended_properties"`
+	ModifiedProperties []string `config:"modified_properties"`
 }
 
 // DefaultConfig sets defaults for configuration options (tune as necessary)
 var DefaultConfig = Config{
-	Period:           60 * 5 * time.Second,
-	RegistryFilePath: "./o365beat.state",
-	APITimeout:       30 * time.Second,
-	ContentMaxAge:    (7 * 24 * 60) * time.Minute,
-	LoginURL:         "https://login.microsoftonline.com",
-	ResourceURL:      "https://manage.office.com",
-	ClientSecret:     "",
-	CertificatePath:  "",
-	CertificatePwd:   "",
+	Period:                 60 * 5 * time.Second,
+	RegistryFilePath:       "./o365beat.state",
+	RegistryOnCorruption:   RegistryOnCorruptionBackup,
+	TimestampFallback:      TimestampFallbackContentCreated,
+	DeadLetterPath:         "./o365beat.deadletter",
+	APITimeout:             30 * time.Second,
+	ShutdownTimeout:        30 * time.Second,
+	ContentMaxAge:          (7 * 24 * 60) * time.Minute,
+	ContentOverlap:         10 * time.Minute,
+	Cloud:                  "commercial",
+	ClientSecret:           "",
+	CertificatePath:        "",
+	CertificatePwd:         "",
+	MaxConcurrentDownloads: 4,
+	APIRetry: RetryConfig{
+		MaxAttempts:    8,
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     2 * time.Minute,
+		MaxElapsed:     15 * time.Minute,
+	},
+	Webhook: WebhookConfig{
+		Listen: ":8443",
+	},
+	ECS: ECSConfig{
+		Enabled: true,
+		Mapping: DefaultECSMapping,
+	},
+	Enums: EnumsConfig{
+		Enabled: true,
+	},
+	Properties: PropertiesConfig{
+		Enabled: true,
+	},
+	Registry: RegistryConfig{
+		Type:  RegistryTypeFile,
+		Flush: time.Second,
+		KV:    KVRegistryConfig{Path: "./o365beat.db"},
+		Elasticsearch: ElasticsearchRegistryConfig{
+			Index:      "o365beat-registry",
+			DocumentID: "o365beat",
+			Timeout:    30 * time.Second,
+		},
+	},
 }
@@ -1,3 +1,84 @@
 // +build !integration
 
 package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveAuthMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		tenant    TenantConfig
+		wantMode  string
+		wantError string
+	}{
+		{"client secret", TenantConfig{ClientSecret: "s"}, AuthModeSecret, ""},
+		{"client secret file", TenantConfig{ClientSecretFile: "secret.txt"}, AuthModeSecret, ""},
+		{"certificate", TenantConfig{CertificatePath: "cert.pem", CertificateKeyPath: "key.pem"}, AuthModeCertificate, ""},
+		{"client assertion", TenantConfig{ClientAssertionPath: "assertion.jwt"}, AuthModeAssertion, ""},
+		{"mode chooses among credentials", TenantConfig{ClientSecret: "s", CertificatePath: "cert.pem", Auth: AuthConfig{Mode: AuthModeCertificate}}, AuthModeCertificate, ""},
+		{"secret and certificate", TenantConfig{ClientSecret: "s", CertificatePath: "cert.pem"}, "", "client_secret and certificate_path are set"},
+		{"secret file and assertion", TenantConfig{ClientSecretFile: "secret.txt", ClientAssertionPath: "assertion.jwt"}, "", "client_secret_file and client_assertion_path are set"},
+		{"secret and secret file", TenantConfig{ClientSecret: "s", ClientSecretFile: "secret.txt", Auth: AuthConfig{Mode: AuthModeSecret}}, "", "client_secret and client_secret_file are set"},
+		{"no credentials", TenantConfig{}, "", "no credentials"},
+		{"mode without its credential", TenantConfig{ClientSecret: "s", Auth: AuthConfig{Mode: AuthModeAssertion}}, "", "auth.mode is assertion, but client_assertion_path isn't set"},
+		{"unknown mode", TenantConfig{ClientSecret: "s", Auth: AuthConfig{Mode: "password"}}, "", "auth.mode must be"},
+	}
+	for _, test := range tests {
+		err := test.tenant.resolveAuthMode()
+		if test.wantError != "" {
+			if err == nil || !strings.Contains(err.Error(), test.wantError) {
+				t.Errorf("%v: got error %v, want %q", test.name, err, test.wantError)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: %v", test.name, err)
+			continue
+		}
+		if test.tenant.Auth.Mode != test.wantMode {
+			t.Errorf("%v: got mode %q, want %q", test.name, test.tenant.Auth.Mode, test.wantMode)
+		}
+	}
+}
+
+func TestTenantConfigsCredentials(t *testing.T) {
+	tenant := func(name, secret, certificate string) TenantConfig {
+		return TenantConfig{Name: name, TenantDomain: name + ".onmicrosoft.com", DirectoryID: name, ClientSecret: secret, CertificatePath: certificate}
+	}
+	tests := []struct {
+		name      string
+		config    Config
+		wantModes []string
+		wantError string
+	}{
+		{"top-level secret", Config{TenantDomain: "contoso.onmicrosoft.com", DirectoryID: "c", ClientSecret: "s"}, []string{AuthModeSecret}, ""},
+		{"top-level secret and certificate", Config{ClientSecret: "s", CertificatePath: "cert.pem"}, nil, "client_secret and certificate_path are set"},
+		{"top-level missing credentials", Config{TenantDomain: "contoso.onmicrosoft.com", DirectoryID: "c"}, nil, "no credentials"},
+		{"tenants inherit the top-level credential", Config{ClientSecret: "s", Tenants: []TenantConfig{tenant("a", "", ""), tenant("b", "", "cert.pem")}}, []string{AuthModeSecret, AuthModeCertificate}, ""},
+		{"tenant with secret and certificate", Config{Tenants: []TenantConfig{tenant("a", "s", ""), tenant("b", "s", "cert.pem")}}, nil, "tenants[1] (b): client_secret and certificate_path are set"},
+		{"tenant missing credentials", Config{Tenants: []TenantConfig{tenant("a", "", "")}}, nil, "tenants[0] (a): no credentials"},
+	}
+	for _, test := range tests {
+		tenants, err := test.config.TenantConfigs()
+		if test.wantError != "" {
+			if err == nil || !strings.Contains(err.Error(), test.wantError) {
+				t.Errorf("%v: got error %v, want %q", test.name, err, test.wantError)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: %v", test.name, err)
+			continue
+		}
+		var modes []string
+		for _, tenant := range tenants {
+			modes = append(modes, tenant.Auth.Mode)
+		}
+		if strings.Join(modes, ",") != strings.Join(test.wantModes, ",") {
+			t.Errorf("%v: got modes %v, want %v", test.name, modes, test.wantModes)
+		}
+	}
+}
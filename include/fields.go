@@ -32,5 +32,5 @@ func init() {
 // AssetFieldsYml returns asset data.
 // This is the base64 encoded gzipped contents of fields.yml.
 func AssetFieldsYml() string {
-	return "eJzsvWtzGzmyIPq9fwWuO+LKnqWoh2XZrRNnZzWSu1t3/NCx5NM7s71hglUgiXYVUA2gRLNv3P9+A5kJFOohibJFj3uPZibGIlkFJBKJRL7ze/bL8bs3Z29++r/YqWZKOyZy6ZhbSMtmshAsl0ZkrliNmHRsyS2bCyUMdyJn0xVzC8FenlywyujfROZG333PptyKnGkF318JY6VWbG+8N94df/c9Oy8Et4JdSSsdWzhX2aOdnbl0i3o6znS5Iwpuncx2RGaZ08zW87mwjmULruYCvvLDzqQocjv+7rtt9lGsjpjI7HeMOekKceQf+I6xXNjMyMpJreAr9iO9w+jto+8Y22aKl+KIbf0PJ0thHS+rre8YY6wQV6I4Ypk2Aj4b8XstjciPmDM1fuVWlThiOXf4sTXf1il3YsePyZYLoQBN4koox7SRc6k8+sbfwXuMXXpcSwsP5fE98ckZnnk0z4wumxFGfmKZ8aJYMSMqI6xQTqo5TEQjNtMNbpjVtclEnP9slryAv7EFt0zpAG3BInpGSBpXvKgFAB2BqXRVF34aGpYmm0ljHbzfAcuITMirBqpKVqKQqoHrHeEc94vNtGG8KHAEO8Z9Ep94WflN39rf3Tvc3n22vf/0cvfF0e6zo6cH4xfPnv5zK9nmgk9FYQc3GHdTTz0Vwxf45wf8/qNYLbXJBzb6pLZOl/6BHcRJxaWxcQ0nXLGpYLU/Ek4znuesFI4zqWbalNwP4r+nNbGLha6LHI5hppXjUjElrN86BAfI1//nuChwDyzjRjDrtEcUtwHSCMDLgKBJrrOPwkwYVzmbfHxhJ4SODibpPV5Vhcw4rnKm9faUG/pJqKsjf+DzOvM/J/gthbV8Lm5AsBOf3AAWf9SGFXpOeAByoLFo8wkb+JN/kn4eMV05Wco/Itl5MrmSYumPhFSMw9P+C2EiUvx01pk6c7VHW6Hnli2lW+jaMa4aqm/BMGLaLYQh7sEy3NlMq4w7oRLCd9oDUTLOFnXJ1bYRPOfTQjBblyU3K6aTA5eewrIunKyKuHbLxCdp/YlfiFUzYTmVSuRMKqeZVvHp7on4WRSFZr9oU+TJFjk+v+kApIQu50ob8YFP9ZU4Ynu7+wf9nXslrfProfdspHTH50zwbBFW2T6s/+tRQz+PRuyRUFf7j/53elT5XCikFOLqx/GLudF1dcT2B+jociHwzbhLdIqIt3LGp36TkQvO3NIfHs8/nb/fZoH21crjnPtDWBT+2I1YLhz+oQ3TUyvMld8eJFftyWyh/U5pwxz/KCwrBbe1EaV/gIaNj3UPp2VSZUWdC/Y3wT0bgLVaVvIV44XVzNTKv03zGjuGCw0WOv4LLZWGtAvPI6eiYcdA2R5+LgsbaA+RZGql/DnRiCAPW7K+cN6XC2FS5r3gVSU8BfrFwkmNSwXG7hGgiBpnWjulnd/zsNgjdobTZV4Q0DNcNJxbfxBHDXxjTwqMBJGp4G6cnN/j89cgktDF2V4Q7Tivqh2/FJmJMWtoI2W+uRYBdcB1Qc5gcobUIi3z1ytzC6Pr+YL9Xovaj29X1onSskJ+FOzvfPaRj9g7kUukj8roTFgr1TxsCj1u62zhmfQrPbeO2wXDdbALQDehDA8iEDmiMEorzekQ1UKUwvDigwxch86z+OSEyhte1DvV157r7ll6GeZgMvdHZCaFQfKRlhD5WM6AAwGbsk8iXQeZxt9kpgTpIAhwPDPa+svfOm78eZrWjk1wu2U+gf3wO0HISJjGC34we7a7O2shorv8yM6+aOnvlfzdizd3X3e8bj2JImHDe0u416eCARnL/Nrl5a3l+f/fxAJJaoHzlXKE3g5axvEpZId4Bc3llQCxhSt6DZ+mnxeiqGZ14Q+RP9S0wjiwW2r2Ix1oJpV1XGUkxnT4kfUTA1PyRELXKWuuU1Fxw0kEoeVbpoTIUf9YLmS26E8VT3amSz+ZF6+TdZ/NvOAbOA8sFVlS+ErPnFCsEDPHRFm5VX8rZ1q3dtFv1CZ28XJV3bB9gdv5CZh1fGUZL5b+n4hbLwraRSBN3FaSxvFdf5uPG9SoyLMjVptnkcRpiqloHoErTM5aG9/sWJcAWptf8mzhVYI+itNxAp5J2dwAqv+T1Ng2sjswHY53x7vbJttPxRjbkmFqp5UudW3ZBVwJt8gzx4rx5hW8Rdjj44sneDBJOiHAMq2UAIXxTDlhlHDs3GinM10QpI/Pzp8wo2tQFysjZvKTsKxWucCL3AtLRhd+MM/dtGGlNoIp4ZbafGS68mqkNl7gCTqeWPBi5l/gzN93hWA8L6WS1vmTeRWEKz9WrkuUxLhjpLbiIspSqxHLCsFNsYrYn4GQG6HVhcxWIFguhBd9YYHjtS9MVZfTKNDcdFUWOt7ara2gKwHH8XqozkC4Ioh620TyRvw6EjztIg30+PjizRNWw+DFqrlxLArPEfV4Js5a605Ib+/Z3uEPrQVrM+dK/gHscdy/Ru5NTHibzANT92D7SWtPF69enSTnIitkR74/ab65QcA/pjf9AQg0wi0RhXTS0yeSY0AdHQsP3kxHFRYFdyPm3OQg0Hl5TSs7Sp5HYW4q0QImtdcIZ4VeMiMyr+u01MnLk3MaFW+LBswebP4L/3gCGRwKK1QU4/0zF/94wyqefRTusX0yhllQA63oWPemQkuPF7dakwb9w4AZS1gPB0nIAUvOcGU5ADNmF7oUUWatLcr+TpiSPQrmK20eNdquEbPAQQgU1VmgxeNAP5Nuhjs7FVE3Ad0sQQAdFQ+WmodtbqZI4Uctk4goTOBvlNrWHiE0aqMUSeXB+61WuAGgI6HWE4yLA4M1+FXa9Yb0wg7u1zacsmDVibYgHG8nzBOtd3B4UHziec6sKLlyMgN+LD45krTEJ5ShRyjYhFNqo7zlNLuSfrnyD9EovH6hwoASbKWrOW3H2YytdG3iHDNeFIH4Apf2HG6uzWrkHw2CgnWyKJhQXuUjukWToRcmcmGdJw+PUo+wmSyKyGR4VRldGcmdKFZ3UHZ4nhth7ab0HKB21GyJtmhCkkkimymncl7r2hYrpGZ4J/L1pUeL1aUAUykrpAVb0tn5iPFw92nDuGf2n5jVnk7GjP2jwSyJTmDLa6TlhWCGLwNMge4nY/pigihrS37KK8aNYJfXaMvD62oyltXEgzIZI1iTEctFJVROojfKzVo1QICaTTvWSDbj/3KXKrfjb/RebWCcrpywt4jAyX6gJaT9WguQv/kf0AoSHRF0TmibkJ310ffioAUYEtsGhHPiqzj+uDXnXOhxJt3qw4YU6RMv2w7uzmsvSwte9MHRykkllNsUTG8SpT5O1oPvjTZuwY5LYWTGB4CslTOrD9LqD5nON4I6nIKdXbxlfooehCfH14K1qd0kkAY39IQrnvcxBSzrdqVzLvSHSst4X7SN6FrNpatzvEML7uBDD4Kt/5c9KrR6dMS2nz8dH+4dvHi6O2KPCu4eHbGDZ+Nnu89+2HvB/r+tHpAb5FNb760w2+GOTH5CKTygZ8TIVoCSkZ6xueGqLriRbpVediuW+UsXRMHkUjsJd1m0xCCFS4NSTiY8FyeBeFZobegyGIHlYSEbcbO5NRC8glWLlZX+j+AJyMKxtgkIb7RLvJ3g55Con5dwac2FDqvt2yum2jqttvOstzdGzKVWmzxp72CGmw7a9n+cXAfXho4awTR40v6jFlPRRpSsboEhPtAmzrPzKDgFjgiXRUpZaLQMBo/ggjs7vzrwX5ydXx02AmFHBip5tgHcvD4+uQ5q1rINu3EXL4PH+hrcXHqVDzWXs3M/EcnxGL/x5vgyKsXssRjPx2R14UWqvDPUAINBpuUCiGcl0QO9oglmOjVnheY5m/KCqwyO7kwasfRqCOjdRtf+RHcw7hddaePuJnQGIcc6I4cl0RQbfvw/Cz5Q37yDvNda9Tm+/VnS3X4bjt6erCN0Xr8f57QH1xF/bYUZD0mU93expXIUmoC0QcOKnxwtsKUAhUPPkn3+sfF5jLwG+Or0+BwcfRkYRE/jUKQUAg/c6q9OlFwWG1qcv7QZTBA4zQB6Z3VRDPD/ewViyzI/DUwLVzW/4rLg06J/LRwXU2EceymVdYK2vQUvWBHGG3OI9p2CM3KAw8TRbwGq6E5VcOfJfACvCOcGEZtSLk7WB2LB7WJjIiFiCo6Jn8ezkkwbIzx/bXnfZ2gRgfOkGFdardJYHuQUydl6bwV5FiewCpmjJQM++NVNYsRHptUM94oXrTm9jJ1x1VjwWIjQGjqFG3Ewv+0IG3WXtOLFDzD0odqQVHax8GwXxWuIxpCqD0hyJDkcyZZZX9c4ZbTqhy+uN+pjYCZD8ojGHxiKgaV6ZniM1mriUNA6h07ccK+AK5ddG3cyY6+FMzJDf7BN/c1csZcn++ht9hQyEy5bCAvaRTI6k85SqE8DpKeudoRaK9RI2ujHbINA45paUQyREaV20evJdO2szEUyUxcyhIkzCnIJCwqbrppXSTNqB9PhoM1AEM1Dk4e73w8rbQMqIewu9tsM9PbNceatywZBOBdEMaUWNJnHyDQ6ZSuWy9lMmFRyA/1PQjyWv9z98dx2QnHlmFBX0mhVtpWHhraOf7mIk8t8FKxzQP/s7buf2FmOsWPgwekd+L7GeHh4+Pz58xcvXvzwQ8cIiTekLKRbffijMdPeN1aPk3mYn8djBW3DQNNwVJpD1GMOtd0W3LrtvY4qRw7/zZHDWQj0ODsN3AtgDYewC6jc3tt/evDs8PmLH3b5NMvFbHcY4g1e2RHmNCSnD3WieMKX/ciSe4PodeADSZDJjWh0++NS5LIu24qB0VcyX8tL8MXGTjhrYcJxOJxpnDRf2hHjf9RGjNg8q0bxIGvDcjmXjhc6E1z1b7qlbS0LrSMbWhQZRz7zuKXXMTJ6wn64kltf3uBrjw+2/ank6eyFsSeRtZXI5EwG20iEAt2F5BIn7VrP0kGSnAhhRZh3IYoqESDhvkKtPA5t6SZUK48gJ6NKtc4FtREZj4TgZvEyb59hWfL5RnlKejZgsugSQICW3LJpLQvnr/MB0ByfbwiyhrIILj5vA5Akatw8e5KwcUPKRpfZwqSU/dCad4O70ay5MXpGboIkuyl2gqOzkis+99Ib8JNIBz1OgokiCRtJvPopIzntfH0DK0kevTn6A6Xn5GnwIqCVa6edMDEwZhLwcVuoB3IfCvX4FmMRWqEUawUkNGIs5ljdU0BCHBYCEx4CEh4CEr69gIT0sAS7NSU5dnH4taISUvb0EJrwEJpwPyA9hCasj7OH0ISH0IQ/U2hCcon92eITWqCzzQQpyMrPlt70t3jmRcslXxl5xZ1gp6//+WTIKQ+nBnSDbyouARzhib2EVgpWlAY3TrPpCjBxKiDb9f5XuIlIgzuIbV8v3OBaWn6IOXiIOXiIOXiIOXiIOfimYg5y1cqxPX1zAR9vsEb+2LJASjX3L7Hfa2GksLBXXNmlSMr4+N8p6ICsWEKCIzfmcDUJsGGslRc5/GnVbC4cprDhsDTo40muLLjwjuD5yROqqLEKk6SjA8sKOWBIUE1tExoRp40GVcuWoij8v7woYu4ywoC+mKUwInjMcuIt0uI4fSjx1cmTu9hLWyu+d0v+1rFi3Bi+CshALNP7WH6AZwsCg1lKtzTC1UYlRz4UxqJYx0Z4goAIqTwMhLLGihn2BrfAilCjqWWkna7Yy5OLJof+HeaO4lgLfiUwxzplFmWzHPwxTK7Y0r/18uSChu/qgH6bPfmB3omSFJYwgF/ahnb/XCBzduxYKZUs63JEX8Zxw6LK2rpWOZ2Jn2XigYOwlt4yvLASLtYRK3nVKLd+tGwBvj8XSrpxyyptrZyiCJNDKiRXK/+vDNm3eHCDNXYYUG5ZhuUtWtb9DkWOs4JvzI6P8Sgc9aO4IcHjkiPFSKiCglI9ZhT3eN3Zm0HQk5ikjYTSALQJdwSTv+hUjaPDITgGBAVLBr5aCZXbIJ1ABAEwrICSdMCw9p5dYm93HP43iIVNWo4AC42o7CkuccV3QGcV5tfadhURzrIFx8vs5M3x65f+QEyFR5Z/v7gS+ShlTltblk1QnGhYjEu8OlqFKixerLGV9igGda45DDAInMsxO4u8SmnHrCyrYtUbM1Q6m0BeeHAhTPzNI6BIYW9blsvleA6W/nGmy8GdcW4dHeI6VdHjHvyVoMVfgSTlOTesFxAwuAmea04Fy3i2SBm7mAFfanmfpM24yUU+Zv8URof4EE/KYXw6Awn+pg3ScIoBz8IwnW4wRudy0cTnfCaLAdJswb0QPBfmw6wIleI2cL6O4c7WM7bPCuGcMMAlcWYGM7eC7Cqsa9IE8hyx4+MRuzwZsXenI/bueMSOT0fs5HTETt/2SJY+brN3p82fbQv+xhQ4v0N+aWg9SRU5bq2cq6T8pdFzw0ukwFiyMyLBPwJiGbock4HAl1/JxkuJzMH2tdnD/b29vda6dTVg2b33xWPhGC8T+MlIjMIYIYHBQB+lyj05oADbkmlZrG+IRaZi5VErXMBdU5UCTfs4DMrIgBmolZiOeS2O/uP9y3f/aOEocsavJjHoWSgxQhcGqia3ygctHr7JqxHuxA5o6dUXPSGdeGOl1XZlpHJQvytbcKhwayx7PBWFXrKn+xCR4CFge/uHT0YJ+WvbeqNh51FJwlIwwma88seKW8H2duEWmcMcv56enj5pJPG/8ewjswW3C1L6fq81eJbjyDTUmF3yqR2xjBsj+VyQ+mBRTC1kEpcwEyJPR8i0uhKGLLS/uhH71eBbvyogQQH2uWKghtgN12zcZiPm0jphRP5hs2ZJv+cLOV8I61gzKUlII7CrVh7nJNrZeho83hEzLQslcqnOOKCtPZppnaz7kT/oj5LP3QJ1yA2o8FwunDAlXH+VEZm0olihhMQx/AXKNQKzraeFzJitZzP5KY4IzzxeOFcd7ezgI/jEWJv5kzG7NCsQhzWWMvkkS+4EXrPTVZCwHP/YGJmRbxfcOqh+hiFnGJnjhQqI+gAd3a/98tVpUyLyUabH9cdHfcK4jSi+krhBUtfN/On4+Lh9zwbJ98OX+ISOewp/UbCzc38jCIianaSK0qSjsYQfJ8FwQLQjZzOZ1QXoo7UVIzYVGa9tNGpecSOFWwVRqznyoPBaL2L6oQisMXuJ9bsb+JIorgCow8qqmoGNJUHOpLn8oJqsdFE5xvTSXHzyb5eeVNKhkbvgS/C74NYLCU7HEZsaQcj0/FU50/0MhCgrdRWx9nd73Q2Ge/VriBVhrmHX8Zu3L9+9e/uuBd0Gz8ZWejiiuZBlvIIa0yNCtL/egP7aFyaUYmoiohNzo1bFCkw4FoowJYbKVlUmeCwzIlSjB/hUU6F4hrB1LY7rQtEAEMyHZFxsAdGZHyqkAhYqYWj9j3WFtpxi5YewWqtQ2ItkPzwdT8bsWOWQ2eQVvzgmYbV99q83ewbroJcKiSf0GGo0I8XiulnLoIztBG4yKL8Wjm+npq8QAE+2rfXLFN5WwXKgDcGX1fhNWjTAPRbx6xdjmdNjNhGZHdNDE3RBBjAaJghyHrCe2jqsiwvelaJXBY2xXxZC4Z7BBmJB4OiXkCqXmbBse5tMLmQOhZLqTjNbyPnCFUPpW8lq4H1qYuFBK4Rn0V4UNFRtjee/eVCD2zlbiJJ38M9aldoHSGdvvDveTSnHGN3KtXgZv7i5aHmT65BBhdtgWoYBLZLvCrSkiMf3WJevRCM3PkdG5aoSEDRbCEwW9GgOjACcXhn3t1Cs6/1deraks6KYNTI7Vzj6HYz+GwoWAmSiCtkxTiKAN2r095nTMeCOHYAgbYZwPRixIcLgYoPqm9LYVadw5surNQvj4/4O5fKEIoDD6TyFDuIspv0YmbVoJZLkMfRaaFfm9OTT5dlJLXqIT1/gPvKmtHy4zV81PSGAsYQ6+S7YCLmLhlmQxNW8GaMpr69nySJovDAUD+XaGVQkD+nAlOTb1BElCw4KvDGMhcYM3hbQDdIIkhGG2A8UI50Kt/RiII9VB+m+Syrv42RUxxML7meFtn5tx2Enbkc3ho7RkFjRt8bg2gJGxCqP8DHtWgAADSM6eYyGber+t7CeUkuD8lKUGtyjwkIVSRouTxDfENxVXShhMA9VNo0V6GGbceWXDm0V7pKSvEZg7GeLgTh6lP2ClaqdvkIKbAz9pJKtif8saRsE1nxpcfca6WLBFZvgA6FW56QxcMSN8Gd9AgjZ5nk+GbEJkfw2kLyAr2ayENsoweUTNDIGU1scMVbzT7ybmF1WFUANQ4nMtRVmu+LWemRuo/+6fV0Q6JvYjpckheMMXeTHS24h5wsq2jrMA4FDBkm6syuNrqZDjdjO5iBBTEZhT61QluygTdguj2BGuJqRg3TEQzndX7jxhxuaacxqqIwQRR8986LQiC0FqwquMPoRfPyMt40dXrDIMlGhQY7s6zEMgNreVNiyy+vCYEzJeD0cSQw7DVlmDWu4Xia4P9XrjO7jLDEyx0VQ06xWx4aEDpKMq+Aw9wsNTDTHnmOxZkDszFOrJP1qRJWkiyY1jCH7w/5BBVfz2v+hDfPLA7kX5E/ktPrKq+iyFF7rCfiMPtWEwjzx/CJVrpcW7312dtrfh4PDgxdt5OOxvuWA5Y3y1sYvcRgcpFfoYrjPmb8QoPVXhN0IDgwjNI3A6tor1Dp7zb/ohKL07vmk9HdqRsGjTbu2WKw4+cqllbZcYxdlzXU20F0t+kK7fPpMsVJbl5RPHlHAh1vqpjMa2fWmYkBFQX4aPmapL7HVHyzjRQZZixSJWoBTEwWFVDsn/xBFuyCJxzFb9zZsC7wa+iIZ64LII3ImO807AiSlVrIpHc6SIba2QI0IO+Y/hioRTrOPQlSsrpBTwEvp4WpjFZpJAKRtPPr7Ck9cxotRurONZX0gdi7njltxW1zwl8fl4jQdZ79q988D6zF4FkpMmuQKHfhkIfKCsjZBMMKodc+JE/5R6PkI9Qr/55NROrk/EWGnUBxYNVmSySnMdJkklXQ7ncBWGpHpsgRODG1WlHZRv4fhvYjQmhs8OzHwoNR5nXR3waDamS4KvUQBgbNcY7kc1RtmwBpT8Wwhxgku4vbWZp10poG4786bUlW1+xB+VFxpii4IQmft0ge4fS2LQg4+g24GoJG9QcI5palbcgODsJI4bZuSkPsg1v1Jxs/CKwdGsI9KL1XagrEVKzLEYQL7gNkVGmloT2UvCFyodRzh110UDai9O6J7PSC9+eswfO8lm6s048rfIOA5oXZknfIJGwwm/pnbBXtcCbPglYWmZNCsaybVXBjwXz4BFwhf0v3ktN8Ajtb5xv4qSq2gEQq2LETzk3SrgeyGUH9m6K/jv52cfjXbxtmpX01Mzk/0lg7Mg/2qPsq1COizNasQJ3CtOoWG6r4MvyRZu1twpMUrkWabizS0hCSdPzHq3qASdNQu+HbSjDmxjjvhFS5ecFNOvk1JHoBsW7NSNr+xuxVnSUIJb2rTBdIFySkgCYGAY+uq0oaaiGZaeZyALA5Do+hS1HNgTjoIQnHYxl/CqR8WXeh4RR/D7QQs4ckoaHc4cgzbG5I5m7RQUOL989ddfS2sB5l0E3h/x5dgfYxaip5BlqmJpPyeJIwbGNk10roXIsBJKfDCyXX2ISnDlEvryTQHBRrzIkBuFtxkC5E3p8ULJDL2nTPCGSmugtA++YB7M+mj8kJUbO8HtvviaP/waG8XiyedvPzxaPf//n5v/+DfLkRW+wXgJ+YWXrdBzdXgd3tjenRvl/5o2II2JbM1SCiz2qsZ1umqEnl4Af+1Jvv3vd2x/+8ey6379/3x3nh/vG8r9+97+0/bCW26dl5W2yTvpCmuY5+tLtCNVcpraxlaMhtOYtsXfGvkpLdb6CfUWATxQWKNhELqSDzjsqiNGGSIccS1GOP6DDGOuz5jrPuC6YZLnG1dRI/s0L6hGQByQZHvhQiSi5UlLaNvNXil54mWXPpjr9scq3EDB9UmHNaBVPeml+4N0YtIWchHL1YWmr4tnKvyJ1gYEXrI1VOqnEIDU+hgbLkaR3z8URglihF7LTOj/fzbtMTtcLi3j+tc+nef9PcR325to5H24web8NbruO2s0HzQZ/NO2o8MRsAusFIb6drtnmn9lkBkVhdAaTYJTHtvBSn7sGRQt8k0gTL/QphuAakI+welTbkGJV67iK03YOSVf4gchr1lQaNohweLVVzErj+Se7u7Ax1FSy4VpiNTXt1K13D02qoyEQJQFAbL2gQg27Z3+CGWHKuWW+GZgGqWgVgjRzMvitDbrKP8WPF7nahO95fDfUEDh3JA1wqwIsIQHgV3OzWWJ5MCKNW2Z7YcgdWGf2wH+ItPPHNMm1wYStMgCSexX5L1skjy+RuLS9Rwe8i6EkmBjHvJwr6gMTtOkUj9Yc6OxP0Lxew3ilcwuzVvpDH+noddQcRIfC4oyZ4KwTnumd1WIxbWVWw92bg6IsLBiUVTSRHqEisrrQPHHhJeiIPocKKt5x3Eet38i5Vw1PBvVcPJ/5Mq4q3b2yvkjSn3Gk3cE8sG69BuJaJlknzXtDdvLWlryybUm3T3ZiSUkgOZYG6rioURPF8Rj87FjNeFC/doY59MWDWa0EJMExb3XUqb2jmPGyEkThpiBiGTgXuC1Ar8r2enNPmjl7XRldg5Lq0TJufloyQYmk+nRlyhSzg8fnH56AlGl7Gffz4qy4a4JS/CU9u7z452dx896ZzlfljcPWkYAskFxC5SbWuMZ4hrOUfJi19pKNEcyxPifvsXIVPbK4MAdYB5JkkbpSiIH8PnG5uH+re6HnNIJOhZBSAYwbKp5wpt9wk59f2v4E0Krmg/NlWFi41B/XQhL5FEJ26tzmTTmB9Uk9A5tNXOEoM5dzzuZNEK0iGL8YjC4iuj8zrDiwGmPAsKGnvdqMf/68ez1/+bnoVIIBqRinxDi1EIGUIJP4jT/fKMfDbDfBzAZmc9gWoii4kxI3erGA7eiS9gg1uvIOhaliisAqiekYWh25mzJLgqyqFtttKiQ8MZnn0MKoW1Q6bTQR/b3UAG9MM4QIN+jnWhbGoztt/vwLhmldG7IJU7Z+S0dmhaKYXjmIkGfv5hNONvMY8XhiFrGvrQ6gouq0npp5qQg8rfvP52ncAqJomVDr1u6FD1h5oK5kDipizFiFnpRSoaDmQq1cAdpAkPRtejBMV0NnSvYaWea2oiR4B6Cmin0FusErMpKGPpmBguGLkoVcfswbiz0KXY4UXAXfQueKD68a33BiucnzhJD6yKpM5YSmxjWX/nRpbcrKhIi7/Ufzo7fXLjvm7t7e7udcrjRR65aQhTVX4Quv5eLrhdjMv82Ybge336DKfoT2oXfG9Ds178fLx3w7T7zw43N/H+s8Mbpn5GBbA2MvWzvf2BqaXaXMjOmR+7iXMOcbzIWFT8O4hT3bOy/+zw6YunnVp3m4P2tQc2OR4eRJ05XnRaePcB3T082O2A+YVX8MANHK9ODr4FOZNdDe0r1X0i3HgNK0ZmB248it60Vm2zHsroj3GXWeul2piFFcV0P8EWhFWYwdqPfR5YcbcpF/SPdVHA+KmQdNNFu3Md4qz8444WrQGh1A/iqR6KMicy3VtVrJgRhbjingC9Jg6BpJBjBJLWI/9xII1x7/BppxKz42Yu3IcNIvUSZkC0es3SrspCqo+dOnQbTBIDXIIX+rFHy8ifA1AmCZInvR2Oml8sxbXRUgWga3t55T3IK6YxVCc5D48vOsIMnp3rRZqkdiuqgKiy/0Qfb9DYfxI6TYzJuDGrtLkWb7zyocBt2keMB0mzbWrFSIGmJm5L9Y+5xEZGT6MT2QLCIxrviofs7DyJU8eYNLNt68rrKfld8mW+nTLg33wJ8G+w/Pc3Vvr7my/7/VDy+9ss+f0tlvv+Bkp999XxcH/FL66/wS5jqdYk764U5KlsEj3hGUrg9I8EmSosUXcj8da5V77psrRfuxZtL26UdvHn8PmW7MkFhoBSq9Kwb40LEX7nxVwb6RZlzJ6ThnyPiVNAFDmeZ0q+LEut4H0RQsFfnz4bgTXiCVBDZQTxtDE7zvMAxiza8LGrJA0xXbFCL4XJuA1qWBs4ZFkeQHS41CoXBt38VlTccKdjyU5usdhJZSR3gj22in9EH+mIYSjDgj/98Gxv/y5VQb+23ejrm4z+Ndair2koiudJ21Y68s/h842OuNDNsOWIw7ihwp+IqnaY+kqtN8PheXlygbmefwmHYNAlLN1iwHEFk+qmq2I78T3kDYNCBmL/YMJrmurq1woYjbmtNOKCm3zJjRixK2lczYvQNdOO2Cm0V0taF2LNlr/XU+hZICxTOhd3akpmsoV0IktC5e61cnQnBqs1X+/e/PTi8MNhW7N/aHX00Oro7iCtq+88tDp60HseWh19jVZH/v7cECRbP9PYaavpVq5iU3wgRrUtQ7HeSYBsAtK0P79UozGoIq3O1Vs3akn3sx5SkVDOScMgjm3EY8iUwD6b1JFhBEGIFK8Y9UGqsw0Bs5TPe2NHeqooWhvQTeqQ3zGZCu6wxHMXC5/XxgokIFkNd3TZTPupn2krh+fcFH2+uZE2k8p/SJUJRSaU+B46rWLIDjFJyB/5veYFuO3imEnx8VBCxgMQqubGyhvQIoMih70Wx3KRyRyKO3nZFcioYexQ2bCz8dqOZ7yUxaYCSN5eMByfPQ62cyPyBXcjloup5GrEZkaIqc1HbIkR/H03CD7Zg7suNtWsqCfz4k60nZuhclqoSjUsgvLM4+C1/o1fie4KkjSEr7AGnC2CDTqX4UuKyO5BfjA+GO9u7+3tb1NNky70GxRorsF/6kOmZVyH8P/ZhTaYob4WxGE+onsvG2k7YvW0Vq6+ida5WcoerQ9WBtwc8OvSyN7ueO9g3K4Buqlw4ktK3+2w3x+1YSeFrvOYiGWpw3mTq0Q3P/peoQrwxO2PS5HLupxA+5KrMi02DWmniawblfURltsLhYy1IdNbq39LvKvjiEN3dqfxU7VmYMh1jvqL2CGBpI4Yvhx6caXb9nT/WXv6h952D73tHnrbPfS2e+ht9y31tls413I5/nx5eQ6frzeu/xhcVDEKxr8Us7nGoXAsm9SmmIS8KoGZky5ZtQfSFE27Jqgwv77zMbww1flqnDbzv2NeZfpqG7lpTFoHTAazdtH74sXz60GkKMoNneFL0vVwM26E8mdRFJottSnyYWg3gMtL7XjRjvLrYvSxBxYOO7bpGZBc9w6eDiO4FG6hN3WPbLVQilN1smqRyDGNFurITkWaH+x0dJhi4cBQnHrMLgQVVtJZXYY43zh26Cf46CxkhXoR+uXJxVDfBuFGrIKislXtBtFkxEwYs7Ew13c0fFMFIcVcbzc977FHOzvTQs/HIbo00+VOB3ZqpPO1zznV/l/zoKdAft2TfhOc1x/1AO/XPusE7ecddgLaOu5qu24HiDtliLdxihMNm9MPdts+yM3qzwDXdQaJPdCPm/C8eXqjv6KPt17oaNDjrfq/GsrxpInl69zMsPgNSDtbb0OivocqupiohHiv3AFWXm0Vy1pyoyYjNoGqh/4POVDbRxjTXo6ez7s8817Wc9kpcoITMakslJlRjFdVQUVnx7G6RW1r8FKkqfTpKNjlC3cTK3vTJRRnGGFVbswWDr1MB22L2szHouDWyQxrJ42nWjvrDK/Gfwt/tZC1yYJSAQOtmg1+50OBKd6tDAh8MnkiUSBmWOm6kNg5WTpWQ63XKONX3LQK+Z6hBd7wpqnDhIYNUi4iPbXVc5VUgvUjpiVMAuHSKGkBpE79I1rsqLegUDMnjgkdf0OdASjigRk7WehKgcHjaKMSKtNgbNaGKbGEVmNesi/1VVpbR7OsEFxBkYo2yF9an4tZTeW3trZAaKJGT80+BVts2obr88t0gSMYjFevV8Qoo18HM+NT1vkm+eqW4L2QV9+OOELLXlnWKpSOhtQQqMZM7LYJb2K4C0l+PkUM2SSvIM70WfFJYfROKcxuxYBYkOkOEUINp9qUFH6MXA6L5kHuRTorXQeV0U5numjXHOZmKp3hpnFCsaY9Jgmram7xUJRQ74lqFoyAAnlhoSVbscKT3zxsP64q0Rh2Zfb7iM14JqZafxwxt5TOof9MWrZMSwtDz9lY7znJfb4SKk/KIkOKDMDSJI54eSSPiSKxvjSegp3cayln55gzY71KYJwdsWTMpTShRMg3qMdw2W48NyCirlMF6FrxdAvlU5RLoawZaC2wI1Ptzw0YfKEKfqt63YRKTMObVFQu6cQRvw9VdEdsEg4r/YR3l2x2wtZlHwFPDzvF1ZGDuNWHjZlKt47R7gcNU6BEADDtZnHQ/s5/R9SU9MBK5ZBw/Jq4mTb/a6QYzpzWxTafK+2lCy9qq5ybPC2GH4edFXqZbsYrwQ21rucu6pFz6Rb1FDRITyBQI30nIm9b5ttesB3IFDxavP1v9s3Bz//t9U/PXv9j58XizPzP89+zg3/+xx+7/97aikgaGxBvHp2GwYMkF9i1M3w2k9n4V/UuqaWdNCv+VbFfI3J+ZX9hUk11rfJfFWN/Ybp2ySdoeq14gZ88BTWfagWE+6v6Vf2yECods+RVlbR5AqaDl9f2lPvNTuqkUrefUbyQEsEmHTNyLj/MlmUQOecXfyXFcowwXDNxQI02rBJGlsIJg4C0gF4PpgaQFgT+X3Cq0WTpyHHS8aMuORHuW3Qz02YJHcF7fSnvEgbT9GFsalLRcU1+IgG5MvrTQCHoH/bHe+O9cbs4qOSKf8BAug0xmLPjN8fsPHCHN1h77nE4ucvlcuxhGGsz38GLGfpW7AR+so3A9b8Yf1q4skgKZl0QH4H7KtTpDG9Z4j+8gGJ/wMFA4nkj3I+FXmLtcviLzNtx3ELPg1JVk317aE39ltgtRG/ah4TC0XRFZS2hZZsOt69tginDvdSF9icwcf4iZ7IFNramusMlPHTh0iCfdeXSuwOXbvPLwLUbfmzkM7qAhy/e/bZFJ1DNJlTZV8+DdtHcmaCBM/FpDDfaiBVAUb/xzEuSofxqI+F+e5JbdCbFQI0A9SZQeAE5RjbScsLEUGoHvzNvir4J9necJz2GsQVjg+GCrzxzqvNqxFxWjZisrg63ZVZWIyZcNn7y7WHeZR3EbyhC5gwvnbcXZ1CzpMBLdJlGsgSyfuWxOPa4O0AMJlpSZUU2YpUsAaHfHjo90IlpgKpSthpvvk2/uykTScXX+3UBK5FJXgQKHsViCBiR2VOpsVpY7MuSCycyNwrjo1UPisTdPuJ2+34j4Qr6q0ItPduuZRBjlaK5MCQg4aBcZQKjSGmpnfqGWs3kvG4aujrNTK3WR0Cs/5zU+m4nRM2kEUteFHbkJVxTQ3AZYkhqtVMZWCIMFcJjgwyZSIlWKKtNLP27FNMWFMkkkI5QaGvZ0NAekcfnrwkbIHYEQAM1pAYcjgXmrrHfhBLYMDjG3KjVKK2Ejuu0kRRsqOuI5GAbgfkGFIdqijQm1VRkr8m2+nstahyYvbx8BSl0WmGlX9L1qNVBuxkskVOwNBkBpkEoXptDb/6AD7+h0Mt4faPTQ9rXQ9rX3UF6SPtaH2cPaV8PaV9/6rSvbtZXvH3b9o/PM8okRpcbh99MmtLr45Prpm/N/pB/Mwj1Q/7NQ/7Nl0H8Xzb/xgojebFZg3HQr2kyuu9vK6R4fy3WHeUCpWw1dLm4oWvcJfhxIQAiKaoTDdHNSKtK2PFQiFJwFZi0p19QPCFkKbfwT2Wp0fqnFfyhi0JATBMqsf6vRgUdiI0IY7ZQ2vI+3ydS48pxhjTAf9yBYOAc3E8QfwNCZCxN2NKcK/lHI+wHM0/3+1viQNJxgn4vlJHZAgkHFPvrOsCXFVfhltaG5NUW0XUiNdLAEBsbKyxEUUG/Im4MV9gUfCYLR10uMAgfxVuFQTrgMWinOEQwmvXcpWLMvyCpJwX1q1UCS+kjigcNV2+RUmTBF02nsZsLu3nRqtUO7xrS6TYxWz9U808pGf7JxcI/sUz4JxII/8TS4DcvCiYe0tiskrjcefLVzXdlc2Fdz9x4mGL4psu4am67JmGRbM6t8TCwMQzHZL6T0DIFlbTiaoEBT8L0FSQuzpxQzDq+sqGJAE7FpLOimDEem1ODgFhJdNRAWmehp7xIuk4FcBuD0nqV2ObrpGt8XgyYMXxF4RKAJG7m4EhL7WSv+YpNBckTuLzKaCcyB84TCSnTqXDXlTvp4zazMZ91m20X8c/aRp1im4X2tu0oCvFJZDV0PNsQKo6n0DZTtArkB6w0s/fL5dfW7Eyl2glre2hmsqGJv7lmJps0whNPJTkjHkWvPELTQJbxohBQnmJueBnzga0sZcHNQJPhDnlW63UqulMm1VkjoaeZ7Ql/EbZ1rqbCj2+Z023MVrdmdN8JrvN4A/Rln/2Ddlxc1Zv7y/FyzqFiFq16yy9vCJCOxeULe3ZeUsvVFsKpN+dA95vdvcPt3Wfb+08vd18c7T47enowfvHs6T87TR0XRvB8vfINd8LQJQzMzk5v3yCCYYOHj4AZFPFx9u3dNkheDto0J4BJOhFgflvh+xHm/SBriI3quI0bj4FmJ1xhYsNUNFWmj+KQSRkaxtnU6KUFa1xIlyIgwu24FFNW8XksCVdADKLq12i4zzI0YUF3qkSz1OajVPMPm25s5/eE5krK0RAvjGJtB9p2Z7sm87UJ1iE5+13y1Y1ydtPaVkBB5FgbfsYzWUjnBeZKXmnYVm50rXIvJ0uRJe22oTtqIDcwWsIDttvWlFJUrN8LqVjJ1corRhmE6zAOAR6hq/JlCgINjUmGYFdFq045os6TnliDfAodtv0UoYihJmcxyNS20ipvWAulpCk2ISyOJ3Elx171yIxw0QjrMdS49YQdJTl9U4GFzMFfGWNtzIhisEcNEYTo1BHLCgk9zMOjXOUxYDENCocSUWCzqyrhd6Ao2Nl5EPWdbqCX1WSE+g4HFUQR0qg0C0YAn50zZ+SV5EWxGjGlWcmdg6QzEe9O6WAybkQ+YtNVDKRLpzri4+k4G+eTu5j+1mkpOOxQPS5iQu/ZucU91qGyVWhNkHghOjF5F+tF5NFzA7l6RDxU3CYGiGVaKYoebCriU4gTdDbPMXbMejXajpLnIe+KTWWMb/YqIIaXZ9rkSc1+bdjlyXnsywtsO4KJsGVCXjXSFKX2sot/vKHQ6sc2NE0KuvLJeQLLGCbBamIxIL47E1VIx/TiFj7C9rXzUpTlNDhwhdAtlmeuDoEUGF0rTMkexfEeYXOKWVT1UihUB3Ab6k/Cz6T6h3iPfpZjYCVUSjxDxmY7U6TrIIZ00ZqAQy9pWAWN2ITnYbWi32qVNbYFPOn09tBgDWqbSkbNkP704jZuYxBNSLqnJ09w+J2whHZjQDSF8Nxz+ZIrJ7OQ8EKZkuIT9sQlftZYKRaiqGZ14R+7kn658g+RuBwUy4QB40yTrBh4lYlzzHhRBF4lqbl1xp2Ya7NCZkVJqtbJomBCQUN7eOyadDOPsJn0Wg0Nm/SIKFZ3MZggJ9+UQIYOPGx1jxsTrw5MdA4MppzKea1rW6yQmuGdKGxBi2Eb9TlwF3LPxkeMh7JzWHkLCrxqTydjxv7RYJZK/KYFlvBUGb5sUoOQ7idj+oLy1tuCpPI3Q5NUnNcYIoq2nom/f6CCFxXzm4xYLvyVBWnkofVB06wf7hlpO1Igt+O1vcfXCYLkCcJx/IWpI5R+kbx2WulS1zY4RQDvzdcRwGBvpqSk44s3T6jAV5G0pbNM8GzRJJ4hKs8gm070IzD3nu0d/tBdc8tF9bW9Ui3wftJ6Xgj26lU7NOy+c23/Bkm20MimSVMmD7imahVyKIB1r9O7cahy5P1UUENocPy24eEhvPghvPjuID2EF6+Ps4fw4ofw4s2HF39mdO9WP7w3BPc2lIVmgU7sDDs7vzrwX5ydXx02AmFHBvpqUcFDIcmKu/EXKOpbl171I2UIbPqp8I4FAd4cX0admLrOSZKWmjOrWWXkFXeCnb7+Z5pY2T4roGEVmudsyguuMjitSTaWNszo2h/icbcVqBv3E1C/3EadIgCSRr9dFHxZ8vY5ZW1/jgzXcabcngd8N0cKof06En+oOP5Qcfyh4vhDxfGHiuPfVMVxqmYGzyV2+/DVLfHVoRZa1wrs0t+0Geiw6SV9Am7JLct0UYgM3N/07XAM9UyqnOpKBuqEUjBIlrFSapjbPxnCFNc3UopqIUpheLHBCl8vwxwpe9Kk3gTwH8sZCLPik7TOPumWd5R50iQN7MmW8cxoa5kREE5ABfMmNCCcvlxDy1HXV2xe8IPZs93dWVtc38Rx2uqz5lCSuFYK3TcIMTubtagJUz0qI23Cc/QMfZvQSBX1xtaSG/Np9L8DwfhrDHqv9hFLr3QNj6sUGCpfVPKPwjLpWKWtlVN0wkf6jCMDnSYlHfBgKNGj2raH0B+YihsnM69hA7xxSFFK56iWbLfc7hvtyKYv0ZWpBFpjLdXlSCt4tcDAtrkttDe5L4n3gJIYNHkYoAUasXTP4eGjxz4VfunTW/70uXgmpjOxy8VhdvDD8/18Kn6Y7e49P+B7h0+fT6cv9g+ez26r2XT/Dd8CsTXJRcSdBvKLUgtGSqXxZMJdCX7gWO6q0EsLtoylji2PbUrNkUwjIzPN0Qhii/89NjpCa4tqRY/IVsks6iAXDwbsVNqosMDqrwSep85cenl/WvuVhxKcuNmmBldwvA/9ZtthukfPZfDU0WJJI6OldCLpqKwN1JTRM/YyrXjcOn+AeiyGEoQIVIBq6ykztUiglP83wZ3tDyGhiXouZrwuHBRJrGJoSMSXpy3y0MQx5cyf1TBG7NY3UMQ6XcN2WoUjiSlzGzGEUk9IGL9Dp/+a/L07nS54MYR7UKUdlN4HpIAWd418LRFnwkqGGmiezXCQpkoKnLo2dG1iHHWoIw4aSzBNWhs/VN08/b21HZvLvNv6z5Ax096Q6GduSWT9XWl4GJR/0h8Z96cGs9mEY1oVq65EdtVMySP59WutjvfHaakndEe3hNPmmxtkU3zq9uCE4O8GqNAys9O+SNsjJVEIt8QfpNYnCkL4Jr3k5O9/8JI/eMkfvOQ3eMnxnNA2pRUvezj8aq5yBOnBVf7gKr8fkB5c5evj7MFV/uAq/1O5yrFw85/NVU5Qs026yulqv8VFzAvyqzanVkfv8aCbOImYZs5wUIDU/Jt3m1+LjvEX4uMbdJuvL9R9Rd/5AM0/+M4ffOcPvvMH3/mD7/yb8p07w7PA0ck8eZl8db198jTxq9Agw15Ernix+kOwShjYUgVWWqPr+ULXYUd5q0cag5RNJzJXe4Wi8OQAYh508WkaPmWZLqtC2oXIwTWUAM7gtXY/aMu2m4szJLstxTQ2YiYz3cxo5baFyjt2922/HGwnaFnJ87iOhi6mPPuYvnmHFqceerE5Zni9uxonTpxo+A2Ca5u1kbMVmtQleXrkTcNaC8zpuXALYSA1MA7Z3K7IOgLCF1zlBW5enAYEsG2SPBOnXV8zO5jOftifPX32/Pn06UHOD/nTTPyw/0O+K3bFwfOnh130xszCfw2S4/QdVIfvQ1rmQs4XHjlR38aWAoLb2pD4Cbmk0dVu65h+x6jkEuHXH78QydhD3+7ubPfwOee7U/7D7v70ecIValOkHOH9u1e3cIP3714F/0Jl9JXMBbN1BfI4VibyUzpgUhAIwAv/CrU1oCdjavJCsKkRHNPc9VJ5ktDMZgvhRQ4UwkZQSIfe1yzIu+sctM0KoafkNCAmbIpRbKv4aLlcht6340w/aruLocJCxsGBAfgs+QoTWinh0mvE2IMB8IoSbrFqCprx9tIYVWUAVzR0rrRiRJnQTdcvsM7Mdew/S94FclD0iKa9hBZeZ4bPy801Kd/yGkbi8atNwfjMUQ3VyfeTBNFOV486TtjJ95PQRZaa5hKvR6A7ksQG6wGezVCA9vQPripZ+v2kEgqQBFtb0ezWKvEJYZ3NuC6p2KQ2Bcj9kxFbLoD1pm3opIW8cGWdqYGbeurBLN9w27UdYqnqNtBYv739RwcHT3fQ7fvX3/+95Qb+3ul1ujjfJ+fFrsSwRmrkDCRiY+2IuNq+KSmJNFIDXVxGadHePJ5O6F4TNnOEpRC4TbeHZ1BNpNBzMnD6V6Wlum+/1dY1adehh49nbNd2QY61NuJrcVgOoteS2wjoqMV4B+PlPmtj/WjX/NwxeFib7OR97/k5Dd8R8zq1nrjblNp0Dp2XW3MnPIgQ9Gh8i9nlHuo/JaaXHhwHB0/7RY8OnraAgjodmzqYnvnCBETE0ZgP8OIvuLbBNaSCzaMOsfV4/F+Bx4tP0MYpacKZzgLhmHjDxo7oSvt34YQmXnysuZ3AHiI5sR43h/mmtYtPjZLJcLEY9RpHjL2wy8o18ADo+OSE3u5EC7XC4dhUuKUQzTUP8uVSo/DQuchQatrU3l7A6NefAeAujzp8FusYTY4G72OE9xo+1VOrN2zrSsMiE+aSQtASk+3tpWIugzWyG9czXIYZHsV7yd/khbji8bImia0d6/NjUsaUX6FzRIBrNDVf+G+ksHQUgtkH2x+7BUdlW+YhZDaI9LFiEt2UcMxsommXdwgQ+j/WFvyvNAP/iSzAfwLj77/a7vtg8r3V5PvNWXu/VUOvf+oDnwddL7myWPPtGhcXjhGuryZ3R5ciFL0OlR3jlUnAXXpllipeL/SS1ZUnqKWYxuhdCF5O2qDA+ipuvBhUR1CD4HSHu0bEQPmvcJJptu6WyPNFCM/8CiV+U4Aa1PWAuuAzbuTX1NTfK9rQq3YEd0NcAxF5f8ii4DvPxrvsMaLx39jJ+XtCKXt7wfb2P+yhaTqU7n/CjquqEL+I6d+l2zncfTbeG+89i+zk8d9/vnz9aoTv/CSyj/oJo5jynb398S57raeyEDt7z17uHbwgPO0c7nY7Fz30QhuE+qEX2kMvtC+D+L9sL7TNgvqffa57zdXgueB3236SIzYV0Bmaq2yhDX7cznRZApgkS/wNn2nN9t9h0JNgZ8FX4PWYjhKUBxAuC6pSCQsE3WYwtwTg7fT4HEJJC5Zu405adWtkD9nYyVL80WRS4MC8kNG0W3G3OCLFu/NwKeeG43zO1KI9Oq6lNaye/iayIOTihw+3ruS/x1ssYhb2MTRFB3RSxk4bAmFMdMt2BadrJ3npX+p0VoEKqHkuHVag9bI75BBRviPME2tRp3vIhrP1rtvBG8BqQEvS4Vob2aOO/ibGhN919g8GHSS7/sCDNHrj6JCCBMEF45Bjui5pX0rMs5VQFRvf9aoRnd6s0HXeHNQT/zEYdSBTkFMpgwFMv6ZfUR7PWq9aTwIUe7GADKwP8MCHMGQoSq5NepRbq4YXxpXRnvQbc0DkQvTL9qebaTQVd+kVT4+UbgMrRmocmFyWfC4Gpual3ObTLN/bfzrISpvZz/wI7Ow02hgQT2EriDa/Z8eeTDBpHpLPIzuIYcnC8XFECSD5FjobfPhGOkvmCAA2RSJuniYuKD5/55nWODqdudY9P8lslFL+IWEwN09GL4yTF9adiy4wWUi3+rDGtXHzW+vOSjS+7sb1zte682AuwVpztB4dHD/wo1xnH4FWiSGdhs8Dxwt/g9TvbkIv/ebPtV1o4z7g/XfEZrywIhFXcL7tyIyuESsiWGzwdrzuFqMbMQ09HEZWgrDhVwaRds1UnuPcfTbgdMmBuuOsnTfXm/Tzpyv4VBTWM87Lt6dvvQS3ZE6zkleeyVrx1x4sLXGK3SxSsZtFC+TpCMI4UK6/zxu6/Rk/DQxy5uWhhFrpWoCyJIHXJATqvx8kT7o3Xp5cpOnbMuZji8yOV2Uxpuew4BA3lGyl1XbzZse0jKDfTOnXb03L/huGmGpdCK7WRO+swQh4Y5pt78+r7Xhay6I/ZX9H4+39aO/F6d7uD4/WA+ftBYMZ2l2EhwDJdC4Gz8FNsFhnhMsW6wMTZkEHi1pFCvxYTyE/BZLhiA7/nn43MG7zexT22pJbMyhLqfBmrtq8dCtnbQF9M811MV7pfJjt3OkwJxioNPZe6m+un6oe4OGfO9O5ztn7s9P+RJCfWPHs/hbVjNifTOc9lv+FkwVjXX8yYpd/+WLGnPz8oeRVJdWcnn30lzVPUQIxXSQlr/ogg5eJ2lB8a3AnsA0DbwQUibDC3e8WN+Nes9G5qAq9gsjJe524GfeaiaEG0Kwu7n3JycDXTH2LHPS5E8dhb512WOj78nlxXLpgmg68vf67A+OG9nHxXolK7dA9kHb3vcslID6tK3aGLmi9hq5sQPSkFf+mC/1R8m1eO51Lm+mrVDn5f/BXdkq/rFj6HEs071utJwNDpbcwwRGHvM78Sc+N0cTUNhffwXYYLMFUf0XPIgCJPXh4TnmTHfo6KyLPFuS/XYBZPHrV2w3RhAz9pDwScpbXEBsItSCx2Wc03oIgrE2JBQui9RMiCCpueCmguJ9hUwH2Sr9vwmGNTgh9gi/8R4zckzmAZsUVVLWsuHEWo9XOzkfBtEStPkcQNQF+qxZIXOXYyhBskkMopHyMyui8ztzdEXlJ1UHw7NIwXkyMa7tp2s8ml9a0Wza6OB4nMz+5ZWqVd6zPa8+M76bFUXD5CS3YWF2wW0smwBHSWu48+/t3r9jCK59QNwymI2oFSG5CelabjtemrSZdM+svMZY/rA8LmiGJk0rJa7cQykksXRFivL/7np2gnwXCMznmGEGUYc4eQ8JasXoCHOnou+9jBHiuMzvGLDw9c+NMlztCbdd2R89mMhP0z/bTw2fbJVd8Lvwlvc0red0vmZNX0q38I9sQ1sm/+569eXv58ojxquLU0jOUcmV8NhOZaxeTYyRC2RH1+cICoKKsCu7EY/vkr98F20CmlQVztvhUcayKNRUrrfI2MpqiIrLdU25WF+Pvwo2gnx4+I5cX3QLJN23Le0vjJh0dwnanq/SlIT5+dhMXNeL3WhqR01V1I7EMZp1xxXidS0eNAdv0+Q6+u+yaR6VyYi5MNLgIZTxnbhW6FKousbyaxFAt9zVJ6HtYU6HnuKrEXnsXhKXlHDX6brViUuVUcG0amhUC5tgFlZs99nO/0vMGe8yhr02bUJivETcIX+DJhA2B3Sj0vGnVmG7JiREAxqXsGMhz7kTcEc92oKlnnjNOuQGechhEpTut//qZ6IBZ/OUFTZ2lYidam1wqwMd7JT275wV6mh6/vzx5wpYLoRofMqX3UiXfsHPtJb4NqL4nuu+WrgVAPF/MS8i9IRggqJd3Kxz5F0ptXXBKRzqwO/SmFJ7vCMEumrKtzSZSQtFboFz29PCZl4UdNfU8gaJGOPNLapTYAWuEbKgyfmLXKnjXqZTEsjIvhMOoa38NmJpab54WVdLfU9pwK50W1bu6EK+5yxaj8Om9yjXDl87UTIc+tFgtMdwP2I729NU5RuLzzgYm9e3ujXv5XfzpPTnPIP8tLde3ZVMUO6E4dPr1i4VevVjCkaowdqtj90YbUXfVIqnQkwwfax+HeorSMZ1ltemc1fdWmOuY5xeyoybiv32iInk27ChA0WdCPd7jRx1Yw9/F6p428VgxXkBRHYiTT1yhGDgciDsP58ZPf5ZH8h+3I+87R8MmlUohhwbjQq2FTFhSJM5O2ePz92enT7DROGarN0icYhlrKMZ5seBGnGup3Ii9VeLUeJj9W3+rrVTCUmJyOLlEbxGekq+wzzmWhl01RIckSa1hYYmnzWsJWEBUBmVVaqAbi1P7mYegj+1noZZeZzd/0eZjofl6ZzJYcm8hyYGDscRrJuHwtJSuAPxO2LpwF4672t4XTGd0P9sk0xUB8WpIrFwdOXO82MMOPAHuaWtIypzVRZCY2XmoV0Htvz1PvPCPidzrhueYsl6sku+0YT9yWXhN8hYmH6hC2qDbXppa4ACFTap7n8VE4NOQ7wW5/35fLRCdvhJmaaQTybDExVKEX3eqPAEe/1Eb0U9nubi88LeaVvE+4c34cdkwQmsmGfcEKiYD4/Lg/Hx5eZ6IVm28/5vnq17wV1sOMlqxviBM334Serp77dKUUgmU3QXJ7YMv0JaOYouAV/4hDBNq+AlOeAMq4K1wBQZfFc43g10fdXcg5Q9wJaXV3I3gljIpQE2AIWg87rw+0zG3vAUz8ppX7BpHx5NBw/SAxQzyvQ7hQp5Pxd2iJY7MZAHkO9OFFxYwx7mRmiEfYfBQBNlpHlOq02HRdN5IOTGfm8ZFKah/id2rIPL+/A17DFkR50aqTFa8gNiiJy0Zc7nQAwLv2lwI2w/AMWoeRKWATFUeSSL/N7wywvEtsXzq/yhXHzD7DD6Ok+R1UizQOBAY9E2XSBNUfvHz8buX52/P3lz+Ss9ow95csuP3lz+/fXd2+Y9fL/5xcfny9RNgjnD7UQJgh/NjW/Gz8/VJl3UNFtbLctJt2fgk9oBJuAavKsGxs7vgpSWudfv+9stKYrZGQ3ZY4y/oNhGL/ifclXF3IGlZLm1V8BXuJnF5KNnRL0VJSlsbaReZrm6K9rvTYf8F5A9JSGGZVyxx9zn420XO3qaXugZYtdqujCilFZZKxvx18F7UqvCs2LMQrfwbKQEmPIaq2cC1EG4xlmEnh2LFLJUub7dwaUBwGoAcf/f/BwAA//+/bVev"
+	return "eNrsvWtzGzmyIPrdvyKvO+LKmqWoh2W32yfOzmosd7fu+KFjyad3ZntDAquSJMZFoAZASWbfuP/9BjIBFKpYerVFj/usOibGIlmVSCQSiUQ+v4NfDj68O3r30/8FhxqUdoCldODm0sJUVgilNFi4ajkC6eBSWJihQiMcljBZgpsjvH51ArXR/8DCjR59BxNhsQSt6PsLNFZqBbvj3fHO+NF3cFyhsAgX0koHc+dq+3J7eybdvJmMC73YxkpYJ4ttLCw4DbaZzdA6KOZCzZC+8mCnEqvSjh892oJPuHwJWNhHAE66Cl/6Bx4BlGgLI2sntaKv4MfwDoS3Xz4C2AIlFvgSNv6Hkwu0TizqjUcAABVeYPUSCm2QPhv8ZyMNli/BmYa/cssaX0IpHH/sjLdxKBxue5hwOUdFZMILVA60kTOpPPnGj+g9gFNPa2npoTK9h5+dEYXDEqZGL1oIIz+wLERVLcFgbdCiclLNaKAAsR1ucMGsbkyBafyjafYC/wZzYUHpiG0FiTwjZo0LUTVISCdkal03lR8mgA2DTaWxjt7voWWwQHnRYlXLGiupWrw+BJrzesFUGxBVxRDsmNcJP4tF7Rd9Y29n9/nWzrOtvaenOy9e7jx7+XR//OLZ079vZMtciQlWdnCBeTX1xHMxfcF/nvH3n3B5qU05sNCvGuv0wj+wzTSphTQ2zeGVUDBBaCyW4DSIsoQFOgFSTbVZCA/Efx/mBCdz3VQlbcNCKyekAoXWYRnQseMA96CqeA0sCINgnfaEEjZimhB4HQl0XuriE5pzEKqE808v7HkgR4+S4T1R15UsBM9yqvXWRJjwE6qLl37Dl03hf87ou0BrxQyvIbDDz26Aij9qA5WeBToQOwRYYfEDNfgn/2T4eQS6dnIhf0ts59nkQuKl3xJSgaCn/RdoElH8cNaZpnCNJ1ulZxYupZvrxoFQLdd3cBiBdnM0/MFCwStbaFUIhypjfKc9EgsQMG8WQm0ZFKWYVAi2WSyEWYLONly+CxdN5WRdpblbwM/S+h0/x2U74GIiFZYgldOgVXq6vyN+xqrS8Is2VZktkROz6zZAzuhyprTBMzHRF/gSdnf29ldX7o20zs8nvGcTpzsxAxTFPM6yu1n/1+OWfx6P4DGqi73H/zvfqmKGijklSPWD9MXM6KZ+CXsDfHQ6R34zrVLYRUG2ChATv8gsBafuUhgELz+dP9+mkffV0tNc+E1YVX7bjaBEx39oA3pi0VygjeyqlV9r7VdKG3DiE1pYoLCNwYV/IIBNj/U3pwWpiqopEf6CwosBmquFhViCqKwG0yj/dhjX2DEdaDTR8Z/CVANIO/cycoKtOCbO9vgLWdnIe/Suh6v8PtFMII9bNr+43y/naHLhPRd1jQpLmuwc86mSYPcEUIEbp1o7pZ1f8zjZl3DEwxXCoseHJk371m/EUYvf2LMCBEVkgsKNs/17cPyWVBJp2xfShMKKi7re9lORBY6h5Y1c+JYaI+mUjnoGyClzi7Tgj1dwc6Ob2Rz+2WDj4duldbiwUMlPCH8V009iBB+wlMwftdEFWivVLC5KeNw2xRyEhTd6Zp2wc+B5wAmRe7yRbURiciZh0lba3YH1HBdoRHUmo9QJ+xk/O1RlK4tWdvWV+7q/l17HMUCWfotMJRpmH2kDIZ/IKWiFLKbsZuLrqNOUoDyhvXYQFThRGG0tGLROGL+fJo2Dc15uWZ7TeviVCMTIhMYLsT99trMz7RCiP/0kzr5o6h+V/GeDv2fe6bj1LMqMTe9d0rk+QSA2luWV0ys70/P/v44JBq3Fg+9IhJUVtCD4KRaHfATN5AUqcBqECq/x0+HnOVb1tKn8JvKbOswwAXaXGn4MGxqksk6oIqgxPXlkxSIIJc8k4TiF9jjFWhgRVBD+T1pQiCXfPy7nspivDpV2dqEXfjCvXmfzPpqC0hAlD02VRVL8Sk8dKqhw6gAXtVuuLuVU684q+oVaxyqeLutrli98RwOAdWJpQVSX/p9EW6FKsPPImrysQRvnd/1pPm5Jo5LMTlRtn2UWD0NMsH2EjjA57Sx8u2J9Bugs/kIUc38lWCVxDifSOVw210Dq/2TIPWL3cHo+3hnvbJliL1djbEeHaZxWeqEbCyd0JNygzxwoEO0rfIrAk4OTTd6YQTsJiBVaKaQL45FyaBQ6ODba6UJXAdMnR8ebYHRD18Xa4FR+RguNKpEPcq8sGV2BZummDSy0QVDoLrX5BLpGI5w2FhKNJzgX1dS/IMCfdxWCKBdSSev8zryIypWHVeoFa2LCQbi28iQWC61GUFQoTLVM1J+Skpuw1ZUsluA0ISrDBMe3PjBVs5ig6XLG4FFZ6XRqd5YiHAkMB0RV6YKUq4DRyjIFfSN9nRief46AnhycvNuEhoBXy/bEsaw8J9LznjjqzDtjvd1nu89/6ExYm5lQ8jcSj+PVY+Te1IT32Tg09ApuP2nt+eLNm1fZvigq2dPvX7XfXKPgH4Q3QdrEI8IGppBOev5kdoykC9vCozfV6QrLirvBmTAlKXReX9PKjrLnWZmbSLaASa1EBdNKX4LBwt91OtfJ01fHASqfFi2aK7j5L/zj7Ui8KSyqpMb7Z07+9g5qUXxC98RujmkUvoHWYVuvDMWWHq9udQYNMLUhMxZaj0fQkCOVnBHKCkJmDCd6gUlnbSzr/g7NAh5H85U2j9vbrsEpmg4qqjdBy9sh/MwbNKzsBNPdhO5mGQEYBfBoqVlc5naIHH++ZcKrzgDCIDS28QQJUNtLkVQevX80iheA7kh86wlvDwFr6au0WwHplR1ery3aZdGqk2xBDG87jpOsd7R5WH0SZQkWF0I5WZA8xs8uaFr4mXXoESs2j5LGE/Utp+FC+unK37C98PqJoqFLsJWuEWE5jqaw1I1JY0xFVUXmi1LaS7iZNsuRfzQqCtbJqgJU/soX+JZNhkKVfkmdZw9PUk+wqayqJGREXRtdGykcVss7XHZEWRq0dl33HOJ2WqrIW2HAoJMkMbOYyFmjG1stmZvpnQAS4NKTxeoFkqkUKmkdCAVHxyMQ8ezTBoQX9p/BemOcGwP8raVsUJ389sy05TmCEZcRp8j35+PwxTmTrKv5KZAuU+zKhm15fFydj2V9DtrA+ZjROh9BiTWqMqjexF6gVYsEXbPHG91VseP/4w5VYcff6Lna4jhZOrQ3qMDZerAlpPtaB5G/+B/YCpIcEWGfhGVicbZKvhf7HcSY2dagnAe5yvDHnTFnqMeFdMuzNV2kX0m3HF6dt16XRlGtoqO9uwaVWxdO77JLfRpsBb932rg5HCzQyEIMINkoZ5Zn0uqzQpdrIR0PAUcn78EPsYLhq4Mr0VrXagaUBhf0lVCiXKUUiaybL50z1Ge1lsoNjftGq5l0TclnaCUcfVjBYOP/hceVVo9fwtb3T8fPd/dfPN0ZweNKuMcvYf/Z+NnOsx92X8D/t7GC5Brl1MZHi2YrnpHZT6yFR/KMINgKaHz/28wI1VTCSLfMDzvv5zDIqmB2qL2KZ1myxDCHS8NaToFeigeFeFppbcJhMCLLw1y26mZ7ajB6FdTzpfVOzOQJKOK2thkK77TLvJ3k55B8P1/QoTVDHWe7aq+YaOu02iqLlbUxOJNarXOnfaARrttoW//x6iq81rTVAk6DO+0/Gpxgl1CyvgEHWQ+NsnF0nBSnKBHpsMg5i42W0eARXXBHxxf7/ouj44vnrULY04EWolgDbd4evLoK665t2I1lfYttfQVtTv2Vj28uR8d+oKDHc/zGu4PTdCmGJziejYPVRVT55d3/53T4CV3HBZD2SnYPBGcEmenUDCotSpiIypv//NadSoOX/hpC925v+UHTp7ifdK2Nu5vSGZUc64xUs5uo4eH/UejB98076HudWR/z279Lu9vr4rGyJrdROq9ej+OwBlcxf2PRjIc0yvs72HI9ik1A2rBhxQ/OFtgF0oVDT7N1/rH1eYxAKHhzeHBMjr6CDKKHCRSjzzJwY3V2uBCyWtPk/KENNECUNAPknTZVdbZG1cEjsWHBD0PD0lEtLoSsvHtnheMOqgkaB6+9xwClWsWXrAjjtTlEV52C0+AAp4GT34Kuott1JZxn8/FVeK6RsDnn8mCrSMyFna9NJWRK+XHAjwNOe3Fg0MvXjvd9yhYR2k8KhNJqmcfysKTI9tZHi8GzeE6zkCVbMuiDn915ivgotJryWomqM6ZQJRRCtRY8iBFaQ7twLQ7m9z1lo+mzVjr4CYdVrNaklZ3MtXFBvaZoDKlWEcm2pKAt2THr66bsWvXjF1cb9TkwE5g9kvGHQAFZqqdGpGitNg6FrXPsxI3nCrlyr447mcJbdEYW7A+2ub9Z+HjVPfY2ew6ZoivmaOl2kUEH6WwI9WmR9NzVjVDrhBpJm/yYXRQCXNOoEENkcKFd8nqCbpyVJWYj9TFjnASEIJc4odyOG14NN6NuMB39kgFy83bwePZ7sNK2qAaC3cV+W9C9fX2SeeO0JRCPBdp0PGAgyxSZFnbZEko5naLJNTf/g5MUjwWCt+eWQyWUA1QX0mi16F4eWt46+OUkDS7LUbTOEf/D+w8/wVE5guTBWdnwqzfG58+ff//99y9evPjhh54Rkk9IWXm71m+tmfa+qXqQjQN+HJDRNkw8TVul3UQrwqGxWyis29rtXeWCw3997HAURoCjwyi9CNe4CfuIyq3dvaf7z55//+KHHTEpSpzuDGO8xiM74ZyH5KxinV086cvVyJJ7w+htlAPL+hqEMjK6vfECS9ksuhcDoy9kiWZNWHaMnbTX4oDjuDnzOGlxaUcgfmsMjmBW1KO0kbWBUs6kE5UuUKjVk+7SdqbF1pE1TSoYR37ndsuPYxb0aDpHcufLa3zt6cGuPzV4OlfC2LPI2hoLOZXRNpKwYHdhcImH27We5kCynAi0GMf1Ds5MgaTzim/lCbQNJ6FaegI5ma5Utzmg1qLjBSW4nbwsu3tYLnzQ9le6BtBgySXACF0KC5NGVs4f5wOoOTFbE2YtZwW8xKyLQJaocf3oWcLGNSkbfWFLg/IY4xviLdcw59bomaQJs+y6xAlDh4VQYua1N5IniQ9WJAknimRiJPPq54LksPf1NaIke/T66A/WnrOnyYvAVq7tbsLEAMws4OOmUA+WPvzeNxmLkBPhdgEJrRpLAO4rICGBpcCEh4CEh4CEby8gId8s0W4dkhz/VVEJuXh6CE14CE14CE14CE14CE14CE24OjQhO8T+aPEJHdTXFKQgaz9aNtJNnnnsuORrIy+EQzh8+/fNIac87Rq6G3xTcQnkCG/1mThTkBZcSxunYbIkShwiZbve/wzXEWlwB7Xt64UbXMnLDzEHDzEHDzEHDzEHDzEH31TMQak6ObaH705uskb+2LFASjXzL8E/GzQSLa2VUPYSszI+/vcQdBCsWCjdPM/hahNgI6ylVzm0Aadhho5T2BhsTMc9L5UlF95Lev58M1TUWMZBcugksmIOGDNUW9skQORhk0HVwiVWlf9XVFUkasCBfTGXaDB6zMogW6RlOKtY8qvnm3exl3ZmfO+W/A2fIW2MWEZiMJXD+zQhsrAxGmBDuqVB1xiVbfnJshPr2MmipwPzEy4DyVorZlwbXgKLYdSukXay9LVaxgniB84dZVhzcYGcY50Li0U7Hf4xDu4tocJ5eAF8/w7ol9mzH907WZPiEgb0S9fQ7p+LbA4HDnzW9qJZjMKXCW6c1KKxLmc5OPejnHvkKKxlZRrStgfrCBaiTiCFh1bMyffnYkk3YaHW1soJqzAliLIkf54oSxmzb3njRmvsMKLCQsHlLTrW/R5HjotKrM2Oz/Eogu9HaUEC8fhyTvvMIC9SyChekXVH7wZRz2KS1hJKQ9hm0pFM/tirGhc2Bwrac7E+SnjVG+9t1E48NiywIklygHHuK3aJ3Z1x/N8gFdZpOSIqtKoyOJ274nuoQ835tbZbRURAMRd8mL16d/D2NUgLE/TE8u9XF1iOcuG0sWHhnNWJVsS4zKujVazCoo1BW2tPYrrOtZuBgNC+HMNRklVKO7ByUVfLFZix0tk55YVHF8I5GKyRihSuLMvl5eV4RpZ+X69xcGWcq77AB+NpT/5KusVfkCYFFguaLxFgcBG81JwgFKKY54IdpySXOt4naQthSizH8Hc0OsaHeFaO8MMeyOg3aYnGQwx4Fob5dI0xOqfzNj7nd4oYYs0O3nMUJZqzaRUrxa1hfx3Qma2nsAcVOoeGpCSPDDRyJ8iu5rombSDPSzg4GMHpqxF8OBzBh4MRHByO4NXhCA7fr7Bs+LgFHw7bPw+ujQC51xXyU2PrSX6RE9bKmcrKXxo9M2LBHJhKdnbywEktY5djBoh8+bVsvZQsHOzqbfb53u7ubmfeuh6w7N775LlwjNSKjLxBjeIYIeRgoE9SlaCnPEPo6LSQ6htykalUedSii7Rrq1KwaZ/BsI5MlJHK6Q7MK2n0Hx9ff/hbh0ZJMn41jUFPw26NBwZfTW7UDzoyfJ1Ho4ffRy0/+pInpBdvrLTaqo1UzuuE/nikCrfGwpMJVvoSnu6BNkAYwO7e881Rxv7adt5oxXm6JHEpGLSFqP22EhZhd4dOkRmN8evh4eFmq4n/RRSfwFbCzsOl75+NdphDDqDGcComvjKMMEaKGYbrg2U1tZJZXMIUscwhFFpdoAkW2l/dCH41/NavilgQyT5XLe90zKZl9n4Q69BgebZes6Rf87mczdE6aAcNGtKI7Kq1p3lQ7WwziR7vYQslS6keHLqtPZ5qnc37MUgLj7PP/QJ1LA1C4bkSHZoFHX+1wUJarJasIQkOf6FyjSRsm0klC7DNdCo/J4j0zJO5c/XL7W1+hJ/wQRabYzg1S1KHNZcy+SwXwiEfs5Nl1LCc+NQamVluV8I6cJc6hJxxZI7SDijqg+7ofu6nbw7bEpGPCz1uPj1eZYybmOIrqRtB67pePh0cHBz0wmZZ8z37Ep/QwcqFv6rg6NiCRVQgFZznF6Xz3o0l/ngeDQeBd+R0KoumcuA0NBZHMMFCNDYZNS+EkeiWUdXqWAuFsyC5PGJAy0cfU/3uFr/WS5cQdVxZVQPZWDLinLeHH1WTlS5djjm9tMTP/u0FCNsBzdKFX6LfUViJBpxOENsaQSz00Cz9JK5Wm/oXse53u/0FpnP1a6gVcaxh1/G7968/fHj/4YZCjPcrI+PmSOZCKERNNaZHgdDahHtB98CkUkxtRPRha27UqlqSCcf6h3JDZacqEz1WGIzV6P1/pWorFE8Zt77F8bZYtAiE3RONix0keuNrFWMZazRh/k90zbacaulBWK1VLOzFD0jeHZtjOFAliHDx64U29vb+1WbPaB3U04j4qkBNZqTIJVh0DMrcTuA6g/JbdGIrN33FAPhg27p9mcKbKlgOtCH4shq/WYsGOscSff1kLDg9hnMs7Dg8dM4uyIhGKwT9XFj0NNZxXVzyrlQrVdAAfpmj4jWjBeSCwMkvIVUpC7SwtRVMLsEc6hECp8FWcjZ31VD6VjYbej80sfCoVegsq4ImVFsT5T88qtHtXMxxIXr0h06l9gHW8T0tdnLOMUZ3ci1epy+uL1re5jr4M6Q1+xNAy+y7pFtSouNHrsu3YCM3PxeMynWNFDRbIScLejJHQUBOr0JYtG1d77woIkhnsZq2OrtQDP0ORv81BQsRMQl43zjJCH61nI4Bd+wABnkzhKvRSA0RBicbr745j130Cme+vrhlYXx6czCXJxYBHE7nqfSsrSC5oOzVDq8kljzwj/Yqcwq1KrOzWvQUnz7ndRRtafl4mr9pe0KQYIl18l20EQqXDLMIDCjBaMvr62k2iQAvghKxXDtQRfKYDhySfNs6osGCwwpvCmMJMKO3BZwGkUeQUEDNYDHSCbpLRD9SrDoYzrus8j4PFup4csH9otLeawwHcSVuJjeHjgWQXNG34eDaiiBylUf6mHctIISGCZ09FsC2df87VM+5pSX5Ahea3KNoPZgIrswI3zLcRVMpNJyHKtH2Hrbeq44lvXSnlGS3nkLRtNEYetL9opWqm74SLrAp9DOUbM38Z1nbILLmS8ur12oXc6HgnB8Iq+hVutZuGhZCGIRzIsiWKMvzEZwHlt8ilkf6aior3GINrjxnI2M0tSWIqZp/5t0ktP04C4pzXT0kvRd/qxbWemJusf+6e1wE1NexHK+DFs4j9ImfDjlv3AhFW4dloH8yadK9VWnvajrWiO0tDjPE+SiuqUVlgx20DdsVCc2EVws5akciltP9RRi/uamZxrTxfNaqPnrqVaERXCLUlVAc/Ug+fhBdY4dXLIoCazbIBft6CgMIbW9qbtnVWGRjSiGa4UhiWmnKMmtFw9U6wf1dvY7CeVxkRuY0idA0q9OxIeODLOMqOswbG7/xkGynZkDqzNOoLP1qFCpJV21qGLD4o6ehEmrW+D+0AT890ntJ/2RJqy/QkJj1t55Iz+RTzTjMzRF+karUl5bPfTg6XF2H/ef7L7rE5219wwYr28tbl75BwjCQlUIXw33O/IFArb8yk7sggRGbRnB17SXfOleaf4UdSixIZ5z0Z2rBpy607dpSseLsK5dX2nKtXRTa42ygu1ryhfbl9JGChbYuK588CgEf7lK3ndGCXW+CA1cUlqfxY5H7Ejv9wQpRFZS1yMTFSiyTopDfzoN/KES7MIsnmJ1z+3IeX419kYx1UeXBEmSveUfEZKGVbEuHQwbC+5h1u2L+Y6wS4TR8QqyhqVlS0Ev55upS1V9DGNMuHYWJOnYhqlG+sq1lfSB2zpvlLLp1HCOduFwepufsV515soGZPAsLTpoUih34wUJU6RloExUjjlr3kjiTH5Wejfhe4f/cHOWDg2w7bbE6sGyzJLNdWOhFllTS73RCS2nQd2AgSUxtVpR26X5P4L2K0BnbU7sNPFjossm6u3BQ7VRXlb5kBUFAqblcjloBM2CNqb13fZzRIi1vY26TzjQQ9917U6q6cWfxRyWUDtEF4XfduPwBYd/KqpKDz7CbgXhkd5BxDsPQHb0BpMqH7XISSx+mut/J/BlVSdvik9KXKm/B2IkVGZIwUXzQ6IqNNGFN5UoQOKrbOMKvOihaVFfOiP7xQEDpOIzfe83mIs+48icIeU5CO7Je+YQ1BhP/LOwcntRo5qK2UMVmXVOpZmjIf7lJLhBxGc4np2GCINg6nyZQ4kIraoSCfDEm85N0y4Hshlh/Zuivg7+8Ovxqto2jQ3A63Urye8tt+lV5C9U6DewxTuDK6xQbqld1+Muga/cLjnRkJfNse5DGlpDhzp8Zda+5EvSuXfTteQvz3Drh0F+4RCXM4vzb1OQJya41KxfzaztbeZQslPC6Nl2kXQQ9xT/BCo5t6lqb0ETUd+fEmqjDoFl1qZoZCScdFaGuf4ENrqEfVjjQ+Yg+oNOJRMLmKN7uGHIK2xvSOdu0ULrE++evOvp6XXxYJ10H3T+IS7I+pluKngIqJ01i5Y9Bw7hGkF2hrSvt2EmJfOCUujhro7eglNazaUkXaM6LIL0ZhfGhde1u8QqJTH3nDDoj8SIq7ednvDbnq6Q8wRp2f4CdFy/3nr/c3SHzELx6/ePLnf/7u929/X87waLxE+BP4OYGheObq+Hvdsfh0d2d8EcrFrRZeD+9Zxwflb8E67SPf4gv8L/WFP++u+M9BONdKK37973x7nhvvGdr9++7e0+7CW26cV5XW6fsDENcJT47XaBbq5RQweYwyiSJ7R7wHchZbzd+MbcI8oNBNAYSho7EUyGrxuCgQEwQbyUYby8QE9zbC8ZmVTFdc4mzjZPkkR1aNzYDUC4oy70YQXKytOGWsWo18Hb19pa8QJWpx9ArzxWvNnGzDqS6t710r4leJCBBjp4sLTV987E25SYXRqQecs0kVE4JgEPoYGq5miA++YRGYTWCt9I7EPXUbYUpbsXNvXXQlNK/u7m6jvx2ZxmNtJ/ObCZbr5K200qLQZ/NB2k/AUHgLrBSG+m67Z7D/G1AEayuiNNsFpjmPXt82acpb9h0DDAbwxwNjq/A/czbaG/BiVdOYuMdGXl9RhyYmyc0SnZ4slilSeyA07C7szPQUdQHe3E6csirW+rGf+pdlXn2zFEcLGszhGzX3uFBXAquWm4RQYBqp8FUC45mUVUBdD8zxPrmaO3V6f5yuE8C4FgO6EoFFsH2HiV3O+MfTQp0qbYrZssROE2BP504V/wsCgfalGhCmkbQcDL7ZbBeVlk+f2txSTfcFWJdYFYg416ysE8CzJ5TJHF/HLNLQPglxOy3F69odmvfyGP8vQy7oIiR9Fy8JHsuJOe4F3YbrVrY1Kn1ZOvqSAQnJ1YYSmKsS6ystM4DD4wX4yB6kmjj+x5h/d38iy/hBOXma3jw/+QX8c7p7S/krSn3ipu4Z5Y11qHdyFTLLPmubW/emZI3abXcm3X3hqCUBgdywLl7VawMinIZZHSJU9FULp6jCWguqtmEFmOauLjvpbS5nfOgVULSoDxkyGQQniG18mexv33z4I9fN0bXuH2wsA5NKRaPs2BoMZkYvGCXcHz85PTxJkeXwc8/v1wsWuaWoopPbe08e7mz83izt5fX1Z/4AzK7+PnGq23D8QxpLseseYkLTSWaU3lCXm//ImVq+8sgYR1x9u7KPArix/j52uah/q2+xxwsulWrAAUjWJggqp77JDj1/a/kTYquaA87VIVLjUH9cDEvMahOwlpdyLYxP11NYufQTjtLDubc9rSLk4OOxXgUwuJro8um4IOBhjyKFzR4216P/9ePR2//d3iWIoECxFDkm1qM+peDhh/V6dXyjGI65XwcomZvPo/6Cl+KGblbxXDyTnyBGNx4Q0HXcoGh8HmFJMgi6G7mbFBcVcihbZfSskPDO4s+xSuFtUOm00Ef291QJvITHOJBP8ZtsWxrM3bf7+F4yyqjdyGqcM7ISePYtLJAJzgTjfz8w2Tm31IeL4EJ1jT2oTW1xwDOF36o8+Cg8ievRAvnNIvzbuPNCQaHKpaxnBc1EPePjsBKVSRwpFOpFu+oTXg0+h4lKqazpnONK/VcURM5IbRyAe0VektVYtaFZYTfhgsmKRqqY67guD3XC9wWVaRdxJWQsuurm037Jw2yglatZh10ZmvL+js2ciHMkqUcHeo/HR1uXruuG7s7O7u98nhJRq4bw/wqP4jd6lp698t4UT5bV/X5w2c8xOqgdi521zTqyc8Hu9cMu/fs+foG3nv2/Jqhn+3urW/oZ7t7A0NLtb6QnSMPu41zjnG8LFhU+juqU/29svfs+dMXTzf6Doq1tULQZWd7eBR14UTVa+G9iujO8/2dHppfeAQPnMDp6BTkW/CByeVXK2v+ric6wg0rrmiSxqPkTevUNlshWfhj3BfW+lKhWee5QQNsUFiFGaz9uCoDa+HW5YL+sakqgp8rSdcdtNtXEc7K3/ALqjGwUuqBgFRclDnT6d6ragkGK7wQyvFNnAJJKceINK3H/uNAGuPu86e9SsxOmBm6szUS9ZRGYLL6m6VdLiqpPvXq0K0xSYxo6V+HJ54sI78PRtBisrmywunmF7Fr1lqqwDM/6SsfSV8xraE6y3l4ctJTZnjvXK3SZLVb8yv7T+HjNTf2n1DniTE+A3uZN9cSrVc+FrjN+4gJld+as+Yi0uY1cTtX/5RLbGTyNDos5hQe0XpXPGZHx1mcOsekmS3vf65kCk67Vb7Mt1MG/JsvAf4Nlv/+xkp/f/Nlvx9Kfn+bJb+/xXLf30Cp79XreDy/0hdXn2CnqVRrlne3wOCpTNcHfiYkcPpHok4Vp6j7kXi/p6/VN1WW9mvXol2JGw2r+HP8fEP25JxDQEOr0rhurQuRfheVz6Rz80XKnpMm+B4zpwBWJe/nkHy5WGhF72MMBX97+GxE1ohN4obaYJBpYzgoy4jGNNnwyfEUQUyWUOlLNIWw8RrWRY4GJwTZ4dKoEg27+S3WwginU8lOYbnYSW2kcAhPrBKf2Ec6AkLVzsXTs2e7e3epCvq17UZf32T0r7EWfU1DUdpP2nbSkX+On691xMVuhh1HHMcNVX5H1I3j1NfQevPRozbF3787/lPcBIMuYenmA44rGlS3XRW7ie8xb5guZKT2Dya85qmufq5E0ZTbGiDOhSl9PNQILqRxjahi10w7gkNqr5a1LiQ3H/y1maBR6NCC0iXeqSmZKebSYZGFyt1r5eheDFZnvJVz8/OL52fP9x9aHT20OnpodfTQ6uih1dF/oVZH/vxcEyYbPwfYeavpTq5iW3wgRbVdxmK95xGzc9Km/f4NNRrjVaTTuXpj/dlUsR8FjSvzMIgDm+gYMyW4z2boyDDyTB3uDO19MNTZpoDZkM97bUf6UFG0MXQ3aWJ+x/kEheMSz30q/L42Vj/T/Orhji7raT/1c1jK4THXxZ/vruXNrPIfc2XGkRknfqROqxyyE4Qk5Y/80xfk8267BDMrPh5LyHgEYtXcVHmDWmSEyGF/i4MSC1miDborsVHeBauxffbXdjwVC1mtK4Dk/QkwfHgSbecGy7lwIyhxIoUawdQgTmzpXYQUwb/qBuEnV/BuqnU1K1rReXklus7NWDktVqUaVkFF4WnwVv9DXGB/BlkawleYA4+W0KY7lxGXISJ7BfP98f54Z2t3d28r1DTpY7/ObrPD9M99yGEaVxH8f/axjWaor4VxHC/wvdeNtB1BM2mUa67jdWEu5QqvD1YGXB/yt+URXwF0f7z7VcKJT0P6bk/8+srCryrdlCkRy4YO522uUjj5aXSuAnzu9sYLLGXjU3CPpnCxyItN+7dzXTdd1kdcbi8WMtYmmN46/VvSWZ0gDp3ZvcZP9S0DQ65y1J+kDglB60jhy029umxP95499LZ76G330NvuobfdQ2+7b7e3nc+P7RjXT0+PbzCu/xhdVCkKxr+UsrnGsXAsnDemOo95VciZky6btUfSVG27Jqowf3vnY3xhosvlOG/mf8e8yvzVLnHzmLQemkCjrpQsefH91SiGKMo1BleRYKbFuBbLn7GqNFxqU5XD2K6BlqfaiQrsdRR94pGlzc5tegY01939p8MEXqCb67UVhumQlIfqZdUyk9NlmOvITjDPD3Y6OUy5cGAsTj2GE+TNUuqiWcQ43wQ79hN8fBSzQr0K/frVyVDfBnQjqKmobN24QTIZnKIxawtz/RDAt1UQcsqtrKaXPfbl9vak0rNx+Na3ndgeLPj/1fc5D3vbjZ4j+XV3+nV4Xr3VI75fe68HbH/fZg9IWydcY2/bAeJOGeJdmvJAw+b0/Z39myvr31/FMI/XVQaJXboft+F5s/xEfxM+3nigs0FPdOr/ag+tk1h+m5OZJr+OC/r7mKjvsUouplBCfKXcAVde7RTLuhTGV8k9p6qH/g85UNsHjelOR89maNYwn9OukysMBFJZSU5CED7SN/iaUnWLxndXqZadVPocCnf54tXkyt7hEEojjLgqN2cLx16mg7ZFbWZjrIR1suDaSeOJ1s46I+rxX+JfX62gVKRAp2aDX/lYYEr0KwOSnMye6LdttHUluXOydNDUIFWr49fCONurJ6qVM6Jt6nAewEYtl4me2+qFyirBeoh5CZPIuAFKXgCpO4042dHKhGLNnASTOv7GOgNWLzBk7BSxKwUHj7ONClWhydisDSi8hEoqtGBwoS/y2joaigqFt/P0Uf7S+lxgdSi/tbFBSlNo9NSpz+VSpfAvLtNFjmAyXr1dBkGZ/DqcGZ+LznfZVzcE74W3exFHbNlbLBoV6M+pIfoCTRS3bXgT8Cpk+fkhYshmeQVppN8VnxSh90ph9isGpIJMd4gQaiXV2lp60lChaB7lXuSj8nhQG+10oatuzWFhJtIZYVonFLTtMYOyqmaWN8WC6j2FmgUj4kBRWWrJVi1557cP20/LGlvDriz+OYKpKHCi9acRuEvpHPvPpIXLvLQwSJXVe85yny9QlVlZZG1i/YQ2ccTrI2VKFEn1pXkXbJdonW/ZRTkz1l8JjLMjyGBeShNLhHyD9xghu43nBlTU21QBulI93WD9lH7nsmZ0a6EVmWi/b8jg65elW73unAnMOnMoKpd14kjfxyq6IziPmzX8xGeXbFfCNotVAjx93iuuzhLELc/WZirdOGC7HzVM8ZNkod1Ojtrf+e8CN2U9sHI9JG6/Nm6mK/9aLUaA07raEjOlvXYB1glVClPmxfBbo2KlL/PFeIPChNb1wqV75Ey6eTOhG6RnEKqRvp2ItyXLLa/YDmQKvpy//2/23f7P/+3tT8/e/m37xfzI/M/jfxb7f/+P33b+faAYwno6ezw+jMCjJhfFtTPC9w4c/6o+ZLW0s2bFvyr4NRHnV/gTSDXRjSp/VQB/At247BM1vVai4k/4Of/UKGLcX9WvyvfQymEuRF1nbZ5I6PDhtTURfrGzOqmh288oHUiZYpPDTJLLg9mwQJFzfvIXEi/HjMMVA0fSaAM1GrlAh4YR6SB9O5xaRDoY+H/JqRYGyyGnQceP++wUaN/hm6k2l9QR/OxLwmDaPoxtTaqwXbOfgoLs+4cOFIL+wRcJ3R13i4NKocQZB9KtKx//4N0BHEfp8I6Ggidx5/petB4H3wZ1mw9m6luxHeXJFiO3+sX489wtqqxg1kmQI3RexTqd8S0b5I+oqNgfSTDSeN6h+7HSl1y7nP4K5u28WH68VDXBvj00p9WW2F81J4WVo8kylLXUxoLT8fS1bTBlPJf62P5EJs5f5FT28tB9a6o7HMJDB24A8ruO3PDuwKHb/jJw7MYfE8h4AA8fvHv7/bKrtLTruMq++T7eLtIwfAMH/DymE20EFXHUP0TxacRE82dvevwb1NySMylSMGG9DhKeUI6RTbycCTHW2snvLNqibwh/5XHybZhaMLYUrsTSC6emrEfginoEsr54viWLRT0CdMV489ujvCvqrxIhc8SHzvuTI6pZUoHrXGz8b5Gt33gqjj3t9pmC2S2ptliMoJYLIui3R06PdGYaCFUpO4033+ffXZeJpNLrq3UBvZ1VVJGDR6kYAkdkrlypuVpY6stSosPCjSJ8eomLxN0Mcat7vgXlivqrUi09261lkGKVkrkwJiAxUKEKpBFCe8F+fUPv2p81bUNXp8E06vYESPWfs1rf3YSoqTR4KarK+hhKZxoKLmMKSa22a0NTJFAxPDaMmmuJFpXVJpX+vcRJB4tsEEpHqLS1MATaE/Lg+G2gBqkdEdHIDbkBR3CBuSvsN7EENgHnmBu1HOWV0HmeNrGCjXUdmR0siFuQOFZTDDBDTUV4G2yr/2ywYcDw+vQNpdBpxZV+w10vtDroNoMN7BSACoOgtOPitSUaLBM9/IJSL+PbG50e0r4e0r7gIe3rIe3rIe3rIe3r6lyejKHa0/c+cpMyo8u14NeTpvT24NVVwz/k3zzk3zzk3zzk36wp/8aikaJar8E43q/DYOG8H3+dPKB520c1F6upu+jVXeNOyY9LARBZUZ1kiG4hLWu046EQpegqMHlPv3jxpJCl0tI/tQ2N1j8v6Q9dVUgxTXyJ9X+1V9CB2IgIsxfFlnmf75OoaeY8Qh7gP745ju5+gvhbFJJgacOWZkLJ31plP5p5+t/fEAeSw4n3e1TGuw2Icehif1UH+EUt1LKNBWF9tcN0vUiNPDDEpsYKc6xqWOoGhDFCcVPwqaxc6HLBQfis3ioO0iGPQTfFIaHRzucuFWP+BUk9OapfrRJYzh9JPWileoeVkgg+aTuNXV/Y7f1JIG6KJxtmnX4Ts9uHav4hNcM/uFr4B9YJ/0AK4R9YG/zmVcHMQ5qaVQYpd5x9df1ZafFm4SbiEMMnXSFUe9q1CYvB5tyBx4GNERzIcjvj5RBU0omr9SPBeXh+XFPi4tShAuvE0sYmAjwUSGex8nb5tBR+VrVkR41/cFbpiaiyrlMR3dagdLtKbDO7thgwY8QyhEsQkYSZkSOtpT7AW7GECQZ9gqfnPdJYOHKeSEqZzpW7vt4ZPm6BTfmsW7BVpT8bm+4UWxDb2z7vtXnBoqGOZ2sixcGE2mZip0B+pEo7+mq5/Maa7YlU23FuD81M4P+QZibrNMIHmRr0jE5+CzUNBG+0RipPMTNikfKBrVzISpiBJsM99qxv16noTplUR62Gnme2Z/IFbWdfTdDDt+B0l7L1jRndd8LrOJ0Aq7rP3n43Lq6u758ux8KgcnHWG1API7J7rz07T0PL1Q7BCehAd62NvZ3d51s7z7b2np7uvHi58+zl0/3xi2dP/95r6jg3KMrx/VPolADD0eHNCxRwWOPmC8gMqvg8+tZOFyXpqrVLAhqkFwHml5W+H3HeD4uG1KhO2LTwNBnv8uTEhgm2VaZf5oUc4kxBwMToS4sGLMZ0qYBEPB0vcQK1mKWScBXFICos11mGJk7oTpVofBiEVLOzdTe282sSxsrK0ehpjvkNne3azNc2WCfo2R+yr67Vs9vWtuhA2rY2/FQUspJOOIRaXmhaVmF86DIIqCUWWbtt6o766FF77vADtt/WNKSoWERFuXRCLf3FqEAbzE2+vHLoqnyao/AoBmlR6V81i1adxYjNVf5dEfVT6rDth4hFDHVwFpNO7XN3y1a08OSlgvNAxfF5mskBFFoVBl0ywoK0mVsP7SjL6ZsgFzInf2WKtTGjEIM9apkgRqeOoKgk9TCPjwpVpoDFPCicSkSRzc5nfJU0xaNj24ZLJexlfT6iJz1Kbo4qEC2UZuEI4KNjcEZeSO/MHoHSsBDOUdIZprNTOhpMGCxHMFmmQLp8qJdiPBkX4/L8Lqa/27QUHHaoHlQpodfnm9Aa61jZKrYmyLwQvZi8k9tF5IXnBnL1AvOE4jZxoTyTqBA92FbEDyFO1Nm85Ngx66/RdpQ9T3lXMJEpvtlfATm8vNCmzGr2awOnr45TX14S2wlNxq1AedFqUyG1F07+9i6EVj+xsWlSvCu/Os5wGcOPqZpYCojvjxQqpFfLFXpkVVuyvBRlRQBOUiF2ixWFa2IgBb3i0CzgcYL3GJwGqkaRgY1YqB7iNtafpJ+Z5VK8x2qWYxQlhIrHhASb7Q2RzyMIpJPOAIJ6SdMsAsQ2PI+rFf2jUUVrW+CdHt4eAtaStq1k1IL0u5eXcYv2TUq6D0++YvDbcQrdxoBsChFlCRYXQjlZxISXkCmJn7knbpBnrZXCm0+mTeUfu5B+ur5sQ+tyUFCgcaKTrBhllUljTH1MZIQZmlsXwuFMmyULq5Ckap2sKkBFDe3psSvSzTzBptLfagLYrEdEtbyLwYQl+boUMuL60OqeFyYdHTSHJGAWEzlrdGOrJXMzvdNtMWzTfY7chcKL8RGIWHaOK29RgVdf4N+NAf7WUjaU+M0LLPGu8ga9lBrEfH8+Dl+EvPWuIqlAuiypuGw4RJRtPef+/KEKXqGY37m35fsjy++y1PqgbdYPHpq0PS1Q2PGtvcdXKYLBE8Rw/IGpE5Z+kqJxWumFbmx0isBp5+uEIP8cAT05OHm3GQp8VVlbOgsoinmbeMakPKJsOlyNwNx9tvv8h/6cOy6qr+2V6qD3k9azCuHNm1drzbX9i/8BLAnGlKYcPOC8TCw2V8nX6904VDnyfiqoMTYMf/wQXvwQXvwQXvwQXvwQXvxfKLz4d0b3bqyG98bg3paz2CzQi52Bo+OLff/F0fHF81YhHG/8a6KCh0KSlXDjL7iob5z6q1+4DJFNP1feuSDAu4PTdCcOXedk0JbaPauhNvJCOITDt3/PEyu7e4VuWJUWJUxEJVRBuzXLxtIGjG78Jh5vrMxzNQH1y23UOQE8/G+YBF+WvH3Mb/8uHa7nTLk5D/hujpRA9qtY/KHi+EPF8YeK4w8Vxx8qjn9TFcdDNbO+3T5+dUN8dXh7xQrs8t+0Geiw6TX9mNYtLBS6qrAg9/e1MdRTqbiCYcudVAqG2TJVSo1j+ydjmOLtjZRYz3GBRlRrrPD1Oo6RiycdrjcR/SdyCloh4GdpnQ9W7JZ3lGXWJI3syRZEYbS1YJDCCULBvPMAkHZfqdGC0m71YvNC7E+f7exMv167tP7cEUyjFLtvGGM44u/D55DqURtpM5mjp+zbpEaqfG/sTLk1nyb/OzFMVfErA4QNr/QNj8scmVC+aCE+oQXpoNbWygk74RN/dksWZSUdeGMoXOHarofQb5haGCcLf8MmfBNIXEjnQi3Zfrndd9oFm75kV6ZCLLn8ELYvhApeHTS4bW6H7G3uS+Y9CEkMOngYqAVaEOnahI+e+qHwyyq/lU+/x2c4meKOwOfF/g/f75UT/GG6s/v9vth9/vT7yeTF3v730+dfveFbmHWWXBSk00B+EaiBF6VtdyadleQHTuWufP08vyzuUqeWx7ZfAYfYNAky024NrdrfU6MjtraoTvSI7JTMmiy7G4NWKm9UWHH114Ce585Sen1/0viZh9dCj0LTKNBZdV6/2HaY72lXY/TUhcmGG1mYSi+SLpS1oZoyegqv84rHnf3n3wvFUKISQS9WjXVoOhYJ1vL/gsLZVRCSmqiXOBVN5UBAoesUGpLo5XkreGgSTDkFpSHCSN36Vlm9U7V5K6/CkcWUubUYQkNPSILf49N/Tf7enXYXvRjDPUKlHdbeB7SAjnRNci1TZ+JMhhpoHk0ZSFslhXZdF7suM4563NE6EON5c95Z+PMbGOMrZd5t/CeD7i9I8jN3NLLVVWllmNNQaf0JhAPBr1p0oFW17GtkF+2QIrHfaq3V8d44L/XE7uiOctp+c41uyk/dHJwQBmCs2DKz3T1Iu5CyKIQb4g9y6xO//G16yXl6D17yBy/5g5f8Oi8575OwTHnFy3+dq5xRenCVP7jKH1zlD67yB1f5g6v8Glc5F27+o7nKA9ZrdZXzIDe5iEUV/Kq5p5h/QjfoJs4ipsEZQRcgNfvm3eZXkmP8hfT4Bt3mt1fqvqLvfIDnH3znD77zB9/5g+/8wXf+TfnOnRFFlOjBPHmafXW1ffIw86sEIMNeRKFEtfwNoUZDS6rISmt0M5vrJq6o6PRIA0rZdFi4xiC5OP20FHIXn7bhU+HdqJW0cyzJNZQhDvRatx+0ha324IzJbpc4ib9HM93UaOW2UJU9u/sWOB3aCVpYiDLNo+WLiSg+5W/eocWpxx7XJwyvdlfzwJkTjb9hdG07t+BspSZ1WZ5e8KZxrQVweoZujoZSAxPI9nRl0REJPheqrHjx0jCkgG0FzTNz2q3ezPYn0x/2pk+fff/95Ol+KZ6LpwX+sPdDuYM7uP/90+erjUMY438RkdPwPVLH72Na5lzO5mhde9/mlgIobGOC+ulFb+tq99WhsiaEIBJ9nQ7P4UDJjp2d6c7z74XYmYgfdvYm32dSoTFVLhE+fnhzgzT4+OFNYGqojb6QJYJtatLHuTKRH9IhUEqioY338cOb0NYgPGmzxM2JQcFp7vpSeZbQYIs5epWDlbARFdIJ72uI+u5tNtp6ldBDgp6EsKlGqa3iY9+UKjjLxoV+3HUXW71gf7EFQfRciCUntLIUpSRy7sFAdGUN1ydjx4Jmojs17uE7Zlc0da60OAqZ0G3XL7LOzHTqPxu8C8FBscI03Sl0a+gZMVusr0n5hr9hZB6/xlQgpi7UUD3/7jwjtNP1454T9vy789hFNjTNDbKeke5pEmusB3g0JejE/yAMglz49QwlFCgJtrHYrtYy8wlxnc00L1+CoDEV6f3nPg+XRG/ehk5aygtX1pmGpKnnHs7yjadd1yGWX90GGut3l//l/v7TbXb7/vmf/95xA3/n9G26ON+n5OWuxFimoZhFbKodkWa7akrKIo3UQBeXUV60t0y7c4Ig0mKOuBSCsPnyiIKqiXinPMPwr0ob6r79o7GuTbuOPXy8YLuyC3KqtZFeS2AFqV7e7x0RHXUE72C83O9aWA/tip97Bg9rs5W87zU/DuB7al6v1pNw87WN7+a9sTMZFAj0eHyD2eUe6j9lppcVPPb3n64WPdp/2kGK6nSsa2N64UsDBCZOxnxw6Ree2+AccsXmcY/ZVmT8n0nG42dq45Q14cxHoXBMPmFTR3Sl/bu0QzMvPh0XOe4xkpPrcQsab9K49NQoG4xeCFGvWbhB6IW9qF2LD6HOT56Ht3vRQp1wOJigu0RUnWgDd6lZeegdZKw1rS0Kg6BfvQdIujzuyVmuY3T+cvA8ZnyvkFMr1+o127qUWJnc+FEXg46abG8uFXMarZH9uJ7hMsz0KJ9LFgxWeCHSYe10e6K1VV+yMqbigp0jSK7R3Hzhv5Fow1aIZh9uf+zmgi/bsowhs1GlTxWTwklJ28xmN+3FHQKE/svagv+VZuA/kAX4D2D8/VfbfR9MvjeafL85a++3auj1T52JWbzrZUcWtN/e4uBiGPH4anN39AJj0etY2TEdmW061DJWvJ7rS2hqkIoMsNHu6+9JeRsUml8tjMUSmoRqVJzucNZgCpT/Cjs5jNZfEnk8j+GZX6t5d8YhTLoVpE7EVBj5NW/qH1VY0ItuBHfLXAMReb/JqhLbz8Y78ITJ+G/w6vhjIKmv1L+7d7bLpulYun8TDuq6wl9w8lfptp/vPPNd6mO5bIAnf/359O2bEb/zExaf9CaEmPLt3b3xDrzVE1nh9u6z17v7LwKdtp/v9DsXPfRCe+iF9tAL7aEX2v31Qlsvqv+5KnWvOBq8FHy05Qd5CRMU7hGAUMVcG/64VejFgtAMusRf+JnOaP/9EYeABjsLv0Kvp3SUeHkg5bIKVSpDN7NHV+SWEL69Hp9DJLm2cWeYdQeyx2zs5AJ/06oLWFQymXa9TfFluHj3Hl7ImRE8njMNdqHzXDpg9eQfWLjUyNt/OLtxJv89C64NlKV1jE3RiZw8WG9+aExyy/YVpysHee1f6nVW8bwuylKGCrRed6ccopDvSOOkWtT5GsJwtt5VK3gNWi1qWTpcZyFXuGN1EVPC723Wj4AOst0q4EEevRY6pSBRcME45pjelrVPJefZSrQpPxVkGXdvUemmbDfqK/8xGnUoU1CEUgYDlH4bfmV9vOi8aj0LhNiLOWVgndEDZxFkLEquTb6VO7OmF8a10Z71W3NAkkLhl63P1/Noru6GV0CqmG5DM2ZuHBhcLnx7ntWhxUJuiUlR7u493b9+9CMPAY4Ok42BAKelCLz5HRx4NqGHdFUGenQQ8oQbJ5IQkW/gs8GHr+WzbIyIYFsk4vph0oRk+XtHusXW6Y112/2TjRZSys8yAXP9YOGFcfbCbccKB5isfLLSLY6N69+67aiBx2+7cCv767bjcC7BrcboPDoIP8qj0udRmlYgHcbPA9uLf6PU735Cb/ht/AjAevPIGZ9/L2EqKouZusLjbSVh9Ogqi3RAY/h0vOoUCydiHno4TKyMYMOvDBLtiqG8xLn7aCTpsg11x1F7b95u0N8/XCUmWFkvOE/fH773GtwlOA0LUYObo8U/r+DSUaduUKluUC1YpjMK48i5/jxv+fZn/jQA5MjrQxm3hmPBvx7rXYwzBvXfD7JnODd8j4ssfVumfGws7Hi5qMbhOS44JExIttJqq31zvNIg/0ZOv3ppOvbfCGKidYVC3ZK805Yi5I1pl311XG3Hk0ZW5S2UxXR6P959cbi788Pj26Hz/gRohG4X4SFECl3i4D64DhfrDLpifntk4ijsYFHLxIGfmgnlp6Bt+fCv+XcDcNvfk7LX1dxaoJBz4fVStX3pRsnaQfpu0rXW5fiW5L6GohkFas29lx4NDtXI8t5GOtYlfDw6XB3I/7+tRXF/k2ohrg6mS7xfCqporFsdLIjLP32xYM5+PluIuvbtlfjZx396fGeMGS1/cKyiTF6m0IbiW8M7w20YeYNUJMKiu98lbuFesdAl1pVeUuTkvQ7cwr1iYK8Iev/ivU85A3zF0DfoQb934AT2xmGHlb4vH5fhhgOm7cC70n93AG74sT1X0qV26BxoYd/tEMDPt1U7wwjjlYauQ6pnmPE/dKU/SbElGqdLaQt9kV9O/h/+FQ7DL0vIn4Ps5n2j9WQAVH4KBzwSyKvMn+G5MZuYuubiO9gOoyU41F/R04RAZg8eHlOWdx/utfDeK/86xRqJ1qvebYiGMvaT8kQooWwoNpBqQXKzz2S8JUVYm4X/UrTWTz8y1MKIBTo/MQMT9CBo3dBxjU4KfaIv/EeO3JMloWbxAo2oPAhnOVrt6JifaJs9j/yjc/JbdVASquRWhmSTHCJhyMeojS6bwt2dkKdzzPZuAANyCmlu1w37u9mlM+yGTS6OJ9nImzcMrUptft/I/G5eHIWnn/GCTdUFpRrGI6a13Hl0Hyw615ccWc/DBW4lTK4jetGYnteme026YtRfUix/nB8XNGMWD1dK0bg5Kie5dEWM8X70HbxiPwuFZwrOMaIowxKeUMJatdwkifTy0XcpArzUhR1zFp6eunGhF9uothq7radTWWD4Z+vp82dbC6HEDP0hvSVqedUvPmJOuqV/ZIvCOsWj7+Dd+9PXL0HUtQgtPWMpVxDTKRauW0wOggplR6HPFxcAxYV3DuITu/nnR9E24JMcvNAE/FwLroo1waVWZZcYbVER2e0pN22q8aN4Iuinz58Fl1c4BbJv+i05sxt3uKNT2O5kmb80JMePrpOiPnhGGizDUXUtswxmnQkFoimlC40Bu/z5gb477ZtHpXI4Q/M7UMgLJGr2hmoFUpWhhNkktv8jXOAkFHA98Bi+0bMWH3DsvdImlrp71CrXHLPnx6EILJ5fpWcBroUnfnolSEW0H/PXrI37X3ry6ZVBwvNULnCl6XGyOvmdTn00yxJECMf3iwVzNAhO6z//TnrRKP68cHKBHudXWptSKiLYRyW9hBUVO3eefDx9tQmXc1St2zZk1IbiuXG/daf4Pq7FPbFav1osIaINiHIhVYsDxdGKflEh/8JCWxf9wIlR7HZ4U6Lf6ohw0lZKbVc55PC8J3kDT58/8+qnC300X6FyIe8UXofehD20Rrzzfds/NK5TY65XnAiKRVmh40BnL3lNE7pdHlZ11lJT2ngQHFb1h6bCt8L5mprh00dVauCXvEUwtn7lAoVRJHMH2MM3xxz8LnoLmJWUOyrvcRV/+hj8VZRyllfI27A5iR0qQc11/WSpPS5XTQyFD/sFqVegjUJD0yoripOBT+WGYwlD6UAXRWN6BksfqbgmedUG2Xd3VGLPVl5FLFal1Ipw8lAH5vBXXN7TIh4oEBXVsaHQ9Mz7SBNKzF3GfeOHPyoT+4+7we69rWGz4qCUtsKhmNZS8mnQ3Y8O4cnxx6PDTRqat0VGxAlXjrYegZO5MHispXIjH197aDzO/q2/NFYqtCEXOO7cwG8Jn4VYcmtxrsa6bJmOWVLYdoqH7WsZWsRUhtXD0LM21YP2Iw9hnzq+Uvm63mr+os2nSovb7cloPL2BJQc2xiUfM5mED1PBlTPdNpU7ccI19r5wOgoHuM2SSxkRr/mnYtFJMqeTP67AJklP21AepA8LD0oqHMcSEaHjtjAIJ/4xLP117JizxKtl9p028KOQFZY3CvkAFaSN18lT0yADqGxWUPso5d4exhQruAzraonp/D390kiHGdggxXKCX7WrPAMe/NYYXM0gOTk98aeaVuk8ES38NG2C0BlJpjWhIsUkuDw6P5+eHrcyq0f3fwPpSNdWG46SSOkVHr77JLVRL9GhWUiFrC5jUJUHXwhLOkpV+d/4hzgyp5UnPOA1pKC34hEY3UM83pRWfdRfgVw+0JGUF1A3KGxIXvAfGUSAJ5y/QvQsHO/Jcnt0b9vZs0Er9EjEDMq9HuNSao23ZXXUkamsiH2nuirRhLTiVq2mFIDBTRF1p1nKYs7BsrW61XJSCvVkmWlBq4fYvSoiH4/fwRMPFY6NVIWsRUXhPJsdHfNyrgcU3ltLIZqioW3UPsjXg2Ad8kTC8t9gmm/fBVcs/R+L5RknfNHHcZYvHm8e/rW4ktceIm0c98nPBx9eH78/enf6a3hGG3h3CgcfT39+/+Ho9G+/nvzt5PT1200SjnT6hZy7nuTnTt5Hx7dnXejbCKzX5aTbsOlJbruSSQ1u7U/N1FEsbJBaN6/vaiVHTpBo2Y7L6sW7TaKi/4lXZdwHJC2U0taVWPJqBikv1GD1x3Bp6xLtpNA13tdm/4X0DxmIAoXBeOsV5OLGEt7nh7omXLXaqg0upEXul4Pmz4PnolaVVHxV1Mq/kTNgJmNCARk6FuIpBgU3T6iWYEO18G7XlBYFpwnJLpn8PfquAaMhCTUZt6MdZARGBCVCqNbCG4TNwfHRjQGjfBf5cvdLHi7IQNtc2oHWAfCkfXCjY4ZjH702bPOlB4Kk2Bxfhf6Xupjbc/MJQ9wEWd5hNquIYWGjt3IFte4d6wbUetHlPvokwIUnPlYlfNhs8duweXTLJRoMfOMvHQMklAu0Tizqs6moKl9u68toeYKulTwRodwuxOeitFT4lDJVmqr04nASM9NG4DRcUka0sy2CIG1sGwRPVtHeZMceKncWpcUT//6k0pMNG397xT9tEoepGVp35kGt0iW5O+w6Y6IOfMuMwPUkzY/TsPAkHLYS7bjFZnPkR+BN7t9Kd0lpgoQbAZVkJXtd+974R20uhSlP9XjAG8i5EGftiF910q/D+Mdp+M7kB9C7mQqrs4y62L9qlm/D+FfMcgC9lVk+oWmW2nFAgSj4NzJ42UIbeoW6O0R6gK64N57Cy3QCqrLV/OOp4r80uNBU5aZloAGsxh90hWeHrC14uT8mCOOBWIqusfj+TpjMuv1kwOKNqllshlnQBYnvR0nMnzhLl6MxfFQWuaJaoz4pfamu5B7KplzDVJIBjDX2Ffw/4Ix6vPlrr7+B3AVnW+h7x5e0vJbq/DEg/J7VKm3gfdCqbo8rXSTXQWBa6dNgmUy3OV/xYaI/J0sB0fr9pWLj+yFWOBPk1b79DGIN7LO1TeUojHCbKd0Fc+H3yBldE/AsFfc4I0DrmMfQnnztB/NzGsEBX+xolhDNPT73OPhliffo+esm+f8PAH/auMc="
 }
@@ -0,0 +1,220 @@
+package o365api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// authInfo holds information returned by the microsoft oauth API
+// (see https://docs.microsoft.com/en-us/office/office-365-management-api/get-started-with-office-365-management-apis#sample-response)
+type authInfo struct {
+	TokenType   string `json:"token_type"`
+	ExpiresIn   string `json:"expires_in"`
+	ExpiresOn   string `json:"expires_on"`
+	NotBefore   string `json:"not_before"`
+	Resource    string `json:"resource"`
+	AccessToken string `json:"access_token"`
+}
+
+func (a *authInfo) header() string {
+	return fmt.Sprintf("%s %s", a.TokenType, a.AccessToken)
+}
+
+// expiresOn is when the token expires (the epoch if unknown, so it's replaced)
+func (a *authInfo) expiresOn() time.Time {
+	expiresOn, _ := strconv.ParseInt(a.ExpiresOn, 10, 64)
+	return time.Unix(expiresOn, 0)
+}
+
+// clientAssertionType is the client_assertion_type for JWT client assertions
+const clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// authHints are added to errors from rejected token requests
+var authHints = map[string]string{
+	AuthModeSecret:      "check client secret and other config details.",
+	AuthModeCertificate: "check the certificate is uploaded to the application registration, and other config details.",
+	AuthModeAssertion:   "check the client assertion (it may have expired) and other config details.",
+}
+
+// authenticate retrieves oauth2 information for use with the API, using a
+// client assertion, a certificate, or a client secret (see tokenProvider).  if
+// azure ad rejects a credential reloaded from changed files, the previous one
+// is tried, so a credential rotated on disk before azure ad accepts it doesn't
+// interrupt collection.
+// https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-client-creds-grant-flow
+func (c *Client) authenticate(ctx context.Context) (*authInfo, error) {
+	current, previous, err := c.credential.get()
+	if err != nil {
+		return nil, err
+	}
+	hint := authHints[c.config.AuthMode]
+	token, err := c.requestToken(ctx, current.form, hint)
+	if err == nil {
+		c.credential.accepted(current)
+		return token, nil
+	}
+	if previous == nil || !isAuthRejected(err) {
+		return nil, err
+	}
+	c.log.Warnf("changed %v credential was rejected, using the previous one: %v", c.config.AuthMode, err)
+	return c.requestToken(ctx, previous.form, hint)
+}
+
+// loadCredential reads the client's credential for its AuthMode from the config
+// or the credential's files (see credentialFiles)
+func (c *Client) loadCredential() (credentialForm, error) {
+	switch c.config.AuthMode {
+	case AuthModeAssertion:
+		return c.loadAssertion()
+	case AuthModeCertificate:
+		return c.loadCertificate()
+	default:
+		return c.loadSecret()
+	}
+}
+
+// credentialFiles are the files the client's credential is read from, which
+// are reloaded when they change
+func (c *Client) credentialFiles() []string {
+	var files []string
+	switch c.config.AuthMode {
+	case AuthModeAssertion:
+		files = []string{c.config.ClientAssertionPath}
+	case AuthModeCertificate:
+		files = []string{c.config.CertificatePath, c.config.CertificateKeyPath}
+	default:
+		files = []string{c.config.ClientSecretPath}
+	}
+	var set []string
+	for _, f := range files {
+		if f != "" {
+			set = append(set, f)
+		}
+	}
+	return set
+}
+
+// loadCertificate loads the certificate and its private key, from a PKCS#12
+// file or PEM files.  each token request sends a new client assertion signed
+// with the key.
+// https://docs.microsoft.com/en-us/azure/active-directory/develop/active-directory-certificate-credentials
+func (c *Client) loadCertificate() (credentialForm, error) {
+	certificate, key, err := loadCertificate(c.config.CertificatePath, c.config.CertificateKeyPath, c.config.CertificatePassword)
+	if err != nil {
+		return nil, err
+	}
+	return func() (url.Values, error) {
+		assertion, err := signAssertion(certificate, key, c.config.ClientID, c.authURL, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		reqBody := url.Values{}
+		reqBody.Set("client_assertion_type", clientAssertionType)
+		reqBody.Set("client_assertion", assertion)
+		return reqBody, nil
+	}, nil
+}
+
+// loadSecret loads the client secret, from the config or its file
+func (c *Client) loadSecret() (credentialForm, error) {
+	secret := c.config.ClientSecret
+	if c.config.ClientSecretPath != "" {
+		data, err := ioutil.ReadFile(c.config.ClientSecretPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the client secret file (%s): %v", c.config.ClientSecretPath, err)
+		}
+		if secret = strings.TrimSpace(string(data)); secret == "" {
+			return nil, fmt.Errorf("client secret file (%s) is empty", c.config.ClientSecretPath)
+		}
+	}
+	return func() (url.Values, error) {
+		reqBody := url.Values{}
+		reqBody.Set("client_secret", secret)
+		return reqBody, nil
+	}, nil
+}
+
+// loadAssertion loads a pre-signed client assertion (a JWT) from its file,
+// which is reloaded when it changes so it can be rotated externally
+// https://docs.microsoft.com/en-us/azure/active-directory/develop/active-directory-certificate-credentials
+func (c *Client) loadAssertion() (credentialForm, error) {
+	data, err := ioutil.ReadFile(c.config.ClientAssertionPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the client assertion file (%s): %v", c.config.ClientAssertionPath, err)
+	}
+	assertion := strings.TrimSpace(string(data))
+	if assertion == "" {
+		return nil, fmt.Errorf("client assertion file (%s) is empty", c.config.ClientAssertionPath)
+	}
+	return func() (url.Values, error) {
+		reqBody := url.Values{}
+		reqBody.Set("client_assertion_type", clientAssertionType)
+		reqBody.Set("client_assertion", assertion)
+		return reqBody, nil
+	}, nil
+}
+
+// authStatusError is returned when a token request gets a non-200 response
+type authStatusError struct {
+	status  int
+	message string
+}
+
+func (e *authStatusError) Error() string {
+	return e.message
+}
+
+// isAuthRejected reports whether err means azure ad rejected the credential
+// (rather than, e.g., being unreachable)
+func isAuthRejected(err error) bool {
+	e, ok := err.(*authStatusError)
+	return ok && (e.status == http.StatusBadRequest || e.status == http.StatusUnauthorized)
+}
+
+// requestToken requests a token with the client credentials grant, adding the
+// grant type, resource and client id to the credential's fields
+func (c *Client) requestToken(ctx context.Context, credential credentialForm, hint string) (*authInfo, error) {
+	c.log.Infof("authenticating via %s", c.authURL)
+	reqBody, err := credential()
+	if err != nil {
+		return nil, err
+	}
+	reqBody.Set("grant_type", "client_credentials")
+	reqBody.Set("resource", c.config.ResourceURL)
+	reqBody.Set("client_id", c.config.ClientID)
+	req, err := http.NewRequest("POST", c.authURL, strings.NewReader(reqBody.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c.log.Debugf("sending auth req: %v", req)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		body, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			body = append(body, fmt.Sprintf("(error reading response body: %v)", err)...)
+		}
+		return nil, &authStatusError{
+			status:  res.StatusCode,
+			message: fmt.Sprintf("non-200 status during auth.\n\t%s\n\treq: %v\n\tres: %v\n\t%v", hint, req, res, string(body)),
+		}
+	}
+	var ai authInfo
+	if err := json.NewDecoder(res.Body).Decode(&ai); err != nil {
+		return nil, fmt.Errorf("error decoding auth response: %v", err)
+	}
+	c.log.Debugf("got auth info: %v", ai)
+	return &ai, nil
+}
@@ -0,0 +1,192 @@
+package o365api
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/youmark/pkcs8"
+	"golang.org/x/crypto/pkcs12"
+)
+
+// loadCertificate reads the certificate and private key for certificate
+// authentication, from a PKCS#12 (.pfx) file or PEM files.  a PEM certificate's
+// key may be in the same file (keyPath empty) or its own file, and may be
+// encrypted with password.  azure ad requires an RSA key.
+func loadCertificate(certPath, keyPath, password string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	certData, err := ioutil.ReadFile(certPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the certificate file (%s): %v", certPath, err)
+	}
+	if !isPEM(certData) {
+		if keyPath != "" {
+			return nil, nil, fmt.Errorf("certificate file (%s) isn't PEM, so it must be PKCS#12 with its own key (don't set a key file)", certPath)
+		}
+		certificate, key, err := decodePkcs12(certData, password)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode pkcs12 certificate (%s): %v", certPath, err)
+		}
+		return certificate, key, nil
+	}
+
+	certificate, err := parsePEMCertificate(certData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("certificate file (%s): %v", certPath, err)
+	}
+	keyData := certData
+	if keyPath != "" {
+		if keyData, err = ioutil.ReadFile(keyPath); err != nil {
+			return nil, nil, fmt.Errorf("failed to read the private key file (%s): %v", keyPath, err)
+		}
+	} else {
+		keyPath = certPath
+	}
+	key, err := parsePEMKey(keyData, password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("private key file (%s): %v", keyPath, err)
+	}
+
+	public, ok := certificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, nil, fmt.Errorf("certificate (%s) must have an RSA public key", certPath)
+	}
+	if public.N.Cmp(key.N) != 0 || public.E != key.E {
+		return nil, nil, fmt.Errorf("certificate (%s) and private key (%s) don't match", certPath, keyPath)
+	}
+	return certificate, key, nil
+}
+
+func decodePkcs12(pkcs []byte, password string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	privateKey, certificate, err := pkcs12.Decode(pkcs, password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rsaPrivateKey, isRsaKey := privateKey.(*rsa.PrivateKey)
+	if !isRsaKey {
+		return nil, nil, fmt.Errorf("PKCS#12 certificate must contain an RSA private key")
+	}
+
+	return certificate, rsaPrivateKey, nil
+}
+
+func isPEM(data []byte) bool {
+	return bytes.Contains(data, []byte("-----BEGIN "))
+}
+
+// parsePEMCertificate returns the first certificate in PEM data
+func parsePEMCertificate(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no PEM certificate found")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
+
+// parsePEMKey returns the first private key in PEM data: PKCS#1 ("RSA PRIVATE
+// KEY", optionally with legacy PEM encryption) or PKCS#8 ("PRIVATE KEY", or
+// "ENCRYPTED PRIVATE KEY" decrypted with password)
+func parsePEMKey(data []byte, password string) (*rsa.PrivateKey, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no PEM private key found")
+		}
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			der := block.Bytes
+			if x509.IsEncryptedPEMBlock(block) {
+				if password == "" {
+					return nil, fmt.Errorf("private key is encrypted, but no password is set")
+				}
+				var err error
+				if der, err = x509.DecryptPEMBlock(block, []byte(password)); err != nil {
+					return nil, fmt.Errorf("error decrypting private key: %v", err)
+				}
+			}
+			return x509.ParsePKCS1PrivateKey(der)
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			return rsaKey(key)
+		case "ENCRYPTED PRIVATE KEY":
+			if password == "" {
+				return nil, fmt.Errorf("private key is encrypted, but no password is set")
+			}
+			key, err := pkcs8.ParsePKCS8PrivateKey(block.Bytes, []byte(password))
+			if err != nil {
+				return nil, fmt.Errorf("error decrypting private key: %v", err)
+			}
+			return rsaKey(key)
+		case "EC PRIVATE KEY":
+			return nil, fmt.Errorf("private key must be RSA (azure ad doesn't accept EC keys)")
+		}
+	}
+}
+
+func rsaKey(key interface{}) (*rsa.PrivateKey, error) {
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key must be RSA (azure ad doesn't accept %T keys)", key)
+	}
+	return rsaKey, nil
+}
+
+// assertionLifetime is how long a signed client assertion is valid
+const assertionLifetime = 10 * time.Minute
+
+// signAssertion returns a client assertion (an RS256 JWT) for the application
+// clientID, signed with the certificate's key for the token endpoint audience
+// https://docs.microsoft.com/en-us/azure/active-directory/develop/active-directory-certificate-credentials
+func signAssertion(certificate *x509.Certificate, key *rsa.PrivateKey, clientID, audience string, now time.Time) (string, error) {
+	thumbprint := sha1.Sum(certificate.Raw)
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"x5t": base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+	})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": audience,
+		"iss": clientID,
+		"sub": clientID,
+		"jti": hex.EncodeToString(jti),
+		"nbf": now.Unix(),
+		"exp": now.Add(assertionLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("error signing client assertion: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
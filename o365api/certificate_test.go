@@ -0,0 +1,190 @@
+// +build !integration
+
+package o365api
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/youmark/pkcs8"
+)
+
+// testCertificate returns a self-signed certificate and its key
+func testCertificate(t *testing.T) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "o365beat test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certificate, key
+}
+
+func writePEM(t *testing.T, path string, blocks ...*pem.Block) string {
+	var data []byte
+	for _, block := range blocks {
+		data = append(data, pem.EncodeToMemory(block)...)
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "o365beat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certificate, key := testCertificate(t)
+	_, other := testCertificate(t)
+
+	certBlock := &pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw}
+	pkcs1 := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := pkcs8.ConvertPrivateKeyToPKCS8(key, []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPath := writePEM(t, filepath.Join(dir, "cert.pem"), certBlock)
+	tests := []struct {
+		name      string
+		certPath  string
+		keyPath   string
+		password  string
+		wantError string
+	}{
+		{"pkcs#1 key file", certPath, writePEM(t, filepath.Join(dir, "pkcs1.pem"), pkcs1), "", ""},
+		{"pkcs#8 key file", certPath, writePEM(t, filepath.Join(dir, "pkcs8.pem"), &pem.Block{Type: "PRIVATE KEY", Bytes: der}), "", ""},
+		{"encrypted pkcs#8", certPath, writePEM(t, filepath.Join(dir, "encrypted.pem"), &pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: encrypted}), "secret", ""},
+		{"encrypted pkcs#8, wrong password", certPath, filepath.Join(dir, "encrypted.pem"), "wrong", "error decrypting private key"},
+		{"encrypted pkcs#8, no password", certPath, filepath.Join(dir, "encrypted.pem"), "", "no password is set"},
+		{"certificate and key in one file", writePEM(t, filepath.Join(dir, "both.pem"), certBlock, pkcs1), "", "", ""},
+		{"mismatched key", certPath, writePEM(t, filepath.Join(dir, "other.pem"), &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(other)}), "", "don't match"},
+		{"no key", certPath, "", "", "no PEM private key found"},
+		{"missing key file", certPath, filepath.Join(dir, "missing.pem"), "", "failed to read the private key file"},
+	}
+	for _, test := range tests {
+		gotCert, gotKey, err := loadCertificate(test.certPath, test.keyPath, test.password)
+		if test.wantError != "" {
+			if err == nil || !strings.Contains(err.Error(), test.wantError) {
+				t.Errorf("%v: got error %v, want %q", test.name, err, test.wantError)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: %v", test.name, err)
+			continue
+		}
+		if !gotCert.Equal(certificate) || gotKey.N.Cmp(key.N) != 0 {
+			t.Errorf("%v: got a different certificate or key", test.name)
+		}
+	}
+}
+
+func TestLoadCertificatePkcs12(t *testing.T) {
+	// testdata/certificate.pfx was exported by openssl with the password "secret"
+	path := filepath.Join("testdata", "certificate.pfx")
+	certificate, key, err := loadCertificate(path, "", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certificate.Subject.CommonName != "o365beat test" || certificate.PublicKey.(*rsa.PublicKey).N.Cmp(key.N) != 0 {
+		t.Errorf("got certificate %v with a key that doesn't match it", certificate.Subject)
+	}
+	if _, _, err := loadCertificate(path, "", "wrong"); err == nil {
+		t.Error("decoded the certificate with the wrong password")
+	}
+	if _, _, err := loadCertificate(path, path, "secret"); err == nil {
+		t.Error("accepted a key file with a PKCS#12 certificate")
+	}
+}
+
+func TestSignAssertion(t *testing.T) {
+	certificate, key := testCertificate(t)
+	now := time.Unix(1577934245, 0)
+	assertion, err := signAssertion(certificate, key, "app", "https://login.example.com/token", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		t.Fatalf("got %v, want a JWT", assertion)
+	}
+	decode := func(part string, v interface{}) {
+		data, err := base64.RawURLEncoding.DecodeString(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != nil {
+			if err := json.Unmarshal(data, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	var header map[string]string
+	decode(parts[0], &header)
+	thumbprint := sha1.Sum(certificate.Raw)
+	if header["alg"] != "RS256" || header["x5t"] != base64.RawURLEncoding.EncodeToString(thumbprint[:]) {
+		t.Errorf("got header %v", header)
+	}
+	var claims map[string]interface{}
+	decode(parts[1], &claims)
+	want := map[string]interface{}{
+		"aud": "https://login.example.com/token",
+		"iss": "app",
+		"sub": "app",
+		"nbf": float64(now.Unix()),
+		"exp": float64(now.Add(assertionLifetime).Unix()),
+	}
+	for name, value := range want {
+		if claims[name] != value {
+			t.Errorf("claim %v: got %v, want %v", name, claims[name], value)
+		}
+	}
+	if jti, _ := claims["jti"].(string); len(jti) != 32 {
+		t.Errorf("got jti %q", claims["jti"])
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("signature doesn't verify: %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+// Package o365api is a client for the Office 365 Management Activity API
+// (https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference)
+// covering authentication, subscription management, content listing and
+// content blob download.  It has no dependencies on libbeat, so it can be used
+// outside o365beat.
+package o365api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Config holds the settings needed to create a Client
+type Config struct {
+	TenantDomain        string // e.g., acme.onmicrosoft.com
+	DirectoryID         string // aka tenant id (GUID), also used as the PublisherIdentifier
+	ClientID            string // aka application id (GUID)
+	AuthMode            string // AuthModeSecret, AuthModeCertificate or AuthModeAssertion
+	ClientSecret        string // for client secret authentication
+	ClientSecretPath    string // file holding the client secret, instead of ClientSecret
+	CertificatePath     string // PKCS#12 (.pfx) or PEM certificate file, for certificate authentication
+	CertificateKeyPath  string // PEM private key file, if not in the PEM certificate file
+	CertificatePassword string // password for the PKCS#12 file or an encrypted private key
+	ClientAssertionPath string // file holding a pre-signed client assertion (JWT), for assertion authentication
+	Cloud               string // endpoint profile (see Clouds), defaults to DefaultCloud
+	LoginURL            string // overrides the cloud's login url
+	ResourceURL         string // overrides the cloud's resource url (which the api is also under)
+	Timeout             time.Duration
+	Retry               RetryPolicy
+	HTTPClient          *http.Client // optional, overrides Timeout
+	TokenCache          *TokenCache  // optional, persists tokens across restarts
+	Logger              Logger       // optional, defaults to discarding log messages
+}
+
+// Default endpoints for the commercial Office 365 cloud (see Clouds for others)
+const (
+	DefaultLoginURL    = "https://login.microsoftonline.com"
+	DefaultResourceURL = "https://manage.office.com"
+)
+
+// Authentication modes (Config.AuthMode), each using one kind of credential
+const (
+	AuthModeSecret      = "secret"      // ClientSecret
+	AuthModeCertificate = "certificate" // CertificatePath (with CertificateKeyPath and CertificatePassword as needed)
+	AuthModeAssertion   = "assertion"   // ClientAssertionPath
+)
+
+// Logger receives the client's log messages (*logp.Logger satisfies this)
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debugf(format string, args ...interface{}) {}
+func (nopLogger) Infof(format string, args ...interface{})  {}
+func (nopLogger) Warnf(format string, args ...interface{})  {}
+
+// Client issues requests to the Management Activity API for a single tenant.
+// It is safe for concurrent use.
+type Client struct {
+	config     Config
+	authURL    string // oauth authentication url built from config
+	apiRootURL string // api root url built from config
+	httpClient *http.Client
+	log        Logger
+	credential *credential
+	tokens     *tokenProvider
+}
+
+// NewClient creates a Client from the given configuration, returning an error
+// if the AuthMode's credential isn't set
+func NewClient(c Config) (*Client, error) {
+	var setting string
+	switch c.AuthMode {
+	case AuthModeSecret:
+		if c.ClientSecret != "" && c.ClientSecretPath != "" {
+			return nil, fmt.Errorf("set ClientSecret or ClientSecretPath, not both")
+		}
+		setting = c.ClientSecret + c.ClientSecretPath
+	case AuthModeCertificate:
+		setting = c.CertificatePath
+	case AuthModeAssertion:
+		setting = c.ClientAssertionPath
+	default:
+		return nil, fmt.Errorf("unknown authentication mode %q", c.AuthMode)
+	}
+	if setting == "" {
+		return nil, fmt.Errorf("authentication mode %v needs its credential set", c.AuthMode)
+	}
+	e, err := endpoints(c.Cloud, c.LoginURL, c.ResourceURL)
+	if err != nil {
+		return nil, err
+	}
+	c.LoginURL, c.ResourceURL = e.LoginURL, e.ResourceURL
+	if c.Retry.MaxAttempts < 1 {
+		c.Retry.MaxAttempts = 1
+	}
+	cl := c.HTTPClient
+	if cl == nil {
+		cl = &http.Client{Timeout: c.Timeout}
+	}
+	var log Logger = nopLogger{}
+	if c.Logger != nil {
+		log = c.Logger
+	}
+
+	// using url.Parse seems like overkill
+	client := &Client{
+		config:     c,
+		authURL:    c.LoginURL + "/" + c.TenantDomain + "/oauth2/token?api-version=1.0",
+		apiRootURL: c.ResourceURL + "/api/v1.0/" + c.DirectoryID + "/activity/feed/",
+		httpClient: cl,
+		log:        log,
+	}
+	client.credential = &credential{files: client.credentialFiles(), load: client.loadCredential, log: log}
+	client.tokens = &tokenProvider{acquire: client.authenticate, cache: c.TokenCache, cacheKey: client.tokenCacheKey(), log: log}
+	return client, nil
+}
+
+// do issues an http request with api authorization header, retrying throttled
+// or temporarily failing requests per the client's RetryPolicy.  body, if not
+// nil, is sent as json.  non-2xx responses are returned as an *APIError.  the
+// caller must close the response body.
+func (c *Client) do(ctx context.Context, verb, urlStr string, query url.Values, body interface{}) (*http.Response, error) {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		if reqBody, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+
+	retry := newRetrier(c.config.Retry)
+	for attempts := 1; ; attempts++ {
+		req, err := c.newRequest(ctx, verb, urlStr, query, reqBody)
+		if err != nil {
+			return nil, err
+		}
+
+		var wait time.Duration
+		var ok bool
+		c.log.Debugf("issuing api request: %s", req.URL.String())
+		res, err := c.httpClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			wait, ok = retry.next(nil)
+			if !ok {
+				return nil, fmt.Errorf("api request failed after %v attempt(s): %v", retry.attempt, err)
+			}
+			c.log.Warnf("api request failed (%v), retrying in %v", err, wait)
+		} else if res.StatusCode < 200 || res.StatusCode > 299 {
+			resBody, readErr := ioutil.ReadAll(res.Body)
+			res.Body.Close()
+			apiErr := newAPIError(req, res, resBody)
+			if readErr != nil {
+				// the status still decides whether to retry
+				apiErr.Message = strings.TrimSpace(fmt.Sprintf("%v (error reading response body: %v)", apiErr.Message, readErr))
+			}
+			if shouldRetry(apiErr) {
+				wait, ok = retry.next(res)
+			}
+			if !ok {
+				apiErr.Attempts = attempts
+				return nil, apiErr
+			}
+			c.log.Warnf("api request throttled or unavailable (%v %v), retrying in %v", res.Status, apiErr.Code, wait)
+		} else {
+			return res, nil
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// newRequest builds an http request with api authorization header
+// (called for each attempt, since request bodies can't be re-read)
+func (c *Client) newRequest(ctx context.Context, verb, urlStr string, query url.Values, body []byte) (*http.Request, error) {
+	req, err := http.NewRequest(verb, urlStr, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	reqQuery := req.URL.Query()                               // keep querystring values from urlStr
+	reqQuery.Set("PublisherIdentifier", c.config.DirectoryID) // to prevent throttling
+	for k, vs := range query {
+		for _, v := range vs {
+			reqQuery.Set(k, v)
+		}
+	}
+	req.URL.RawQuery = reqQuery.Encode()
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// authenticate if the token is missing or expired
+	token, err := c.tokens.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", token.header())
+	return req, nil
+}
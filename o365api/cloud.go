@@ -0,0 +1,52 @@
+package o365api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CloudEndpoints are the login (azure ad) and resource (api) urls of an
+// office 365 cloud, with https:// and no trailing slash
+type CloudEndpoints struct {
+	LoginURL    string
+	ResourceURL string
+}
+
+// Clouds are the endpoint profiles of the office 365 clouds (Config.Cloud)
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#activity-api-operations
+var Clouds = map[string]CloudEndpoints{
+	"commercial": {LoginURL: DefaultLoginURL, ResourceURL: DefaultResourceURL},
+	"gcc":        {LoginURL: "https://login.microsoftonline.com", ResourceURL: "https://manage-gcc.office.com"},
+	"gcc_high":   {LoginURL: "https://login.microsoftonline.us", ResourceURL: "https://manage.office365.us"},
+	"dod":        {LoginURL: "https://login.microsoftonline.us", ResourceURL: "https://manage.protection.apps.mil"},
+	"china":      {LoginURL: "https://login.chinacloudapi.cn", ResourceURL: "https://manage.office.cn"},
+}
+
+// DefaultCloud is used when Config.Cloud is empty
+const DefaultCloud = "commercial"
+
+// endpoints returns the cloud's endpoints with any overrides applied
+func endpoints(cloud, loginURL, resourceURL string) (CloudEndpoints, error) {
+	if cloud == "" {
+		cloud = DefaultCloud
+	}
+	e, ok := Clouds[cloud]
+	if !ok {
+		names := make([]string, 0, len(Clouds))
+		for name := range Clouds {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return e, fmt.Errorf("unknown cloud %q (must be one of %v)", cloud, strings.Join(names, ", "))
+	}
+	if loginURL != "" {
+		e.LoginURL = loginURL
+	}
+	if resourceURL != "" {
+		e.ResourceURL = resourceURL
+	}
+	e.LoginURL = strings.TrimRight(e.LoginURL, "/")
+	e.ResourceURL = strings.TrimRight(e.ResourceURL, "/")
+	return e, nil
+}
@@ -0,0 +1,64 @@
+// +build !integration
+
+package o365api
+
+import (
+	"testing"
+)
+
+func TestCloudEndpoints(t *testing.T) {
+	tests := []struct {
+		cloud, loginURL, resourceURL string
+		wantAuth, wantAPI, wantToken string
+	}{
+		{"", "", "",
+			"https://login.microsoftonline.com/contoso.onmicrosoft.com/oauth2/token?api-version=1.0",
+			"https://manage.office.com/api/v1.0/d/activity/feed/", "https://manage.office.com"},
+		{"commercial", "", "",
+			"https://login.microsoftonline.com/contoso.onmicrosoft.com/oauth2/token?api-version=1.0",
+			"https://manage.office.com/api/v1.0/d/activity/feed/", "https://manage.office.com"},
+		{"gcc", "", "",
+			"https://login.microsoftonline.com/contoso.onmicrosoft.com/oauth2/token?api-version=1.0",
+			"https://manage-gcc.office.com/api/v1.0/d/activity/feed/", "https://manage-gcc.office.com"},
+		{"gcc_high", "", "",
+			"https://login.microsoftonline.us/contoso.onmicrosoft.com/oauth2/token?api-version=1.0",
+			"https://manage.office365.us/api/v1.0/d/activity/feed/", "https://manage.office365.us"},
+		{"dod", "", "",
+			"https://login.microsoftonline.us/contoso.onmicrosoft.com/oauth2/token?api-version=1.0",
+			"https://manage.protection.apps.mil/api/v1.0/d/activity/feed/", "https://manage.protection.apps.mil"},
+		{"china", "", "",
+			"https://login.chinacloudapi.cn/contoso.onmicrosoft.com/oauth2/token?api-version=1.0",
+			"https://manage.office.cn/api/v1.0/d/activity/feed/", "https://manage.office.cn"},
+		// overrides win over the cloud's endpoints, without trailing slashes
+		{"gcc_high", "", "https://proxy.example.com/",
+			"https://login.microsoftonline.us/contoso.onmicrosoft.com/oauth2/token?api-version=1.0",
+			"https://proxy.example.com/api/v1.0/d/activity/feed/", "https://proxy.example.com"},
+		{"china", "https://login.example.com/", "",
+			"https://login.example.com/contoso.onmicrosoft.com/oauth2/token?api-version=1.0",
+			"https://manage.office.cn/api/v1.0/d/activity/feed/", "https://manage.office.cn"},
+	}
+	for _, test := range tests {
+		c, err := NewClient(Config{
+			Cloud:        test.cloud,
+			LoginURL:     test.loginURL,
+			ResourceURL:  test.resourceURL,
+			AuthMode:     AuthModeSecret,
+			ClientSecret: "s",
+			TenantDomain: "contoso.onmicrosoft.com",
+			DirectoryID:  "d",
+		})
+		if err != nil {
+			t.Errorf("cloud %q: %v", test.cloud, err)
+			continue
+		}
+		// tokens are requested for the resource url
+		if c.authURL != test.wantAuth || c.apiRootURL != test.wantAPI || c.config.ResourceURL != test.wantToken {
+			t.Errorf("cloud %q: got %v, %v and resource %v, want %v, %v and %v", test.cloud,
+				c.authURL, c.apiRootURL, c.config.ResourceURL, test.wantAuth, test.wantAPI, test.wantToken)
+		}
+	}
+
+	if _, err := NewClient(Config{Cloud: "gallifrey", AuthMode: AuthModeSecret, ClientSecret: "s"}); err == nil {
+		t.Error("no error for an unknown cloud")
+	}
+}
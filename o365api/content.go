@@ -0,0 +1,154 @@
+package o365api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Content describes an available content blob
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#list-available-content
+type Content struct {
+	ContentType       string    `json:"contentType"`
+	ContentID         string    `json:"contentId"`
+	ContentURI        string    `json:"contentUri"`
+	ContentCreated    time.Time `json:"contentCreated"`
+	ContentExpiration time.Time `json:"contentExpiration"`
+}
+
+// MaxContentSpan is the longest span of time ListContent can request at once
+const MaxContentSpan = 24 * time.Hour
+
+// dateFmt is the format the API needs for start and end times (UTC, no "Z" suffix)
+const dateFmt = "2006-01-02T15:04:05"
+
+// ContentIterator pages through available content returned by ListContent:
+//
+//	it := client.ListContent(ctx, "Audit.Exchange", start, end)
+//	for it.Next() {
+//	    c := it.Content()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type ContentIterator struct {
+	client  *Client
+	ctx     context.Context
+	nextURL string     // next page to request, "" once the last page has been requested
+	query   url.Values // query for the first page (NextPageUri includes its own)
+	page    []Content
+	current Content
+	err     error
+}
+
+// ListContent lists content blobs of a single content type created between start
+// and end, which must be <=24 hours apart (the basic primitive provided by the
+// API).  Pages (following NextPageUri) are requested as the iterator advances.
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#list-available-content
+func (c *Client) ListContent(ctx context.Context, contentType string, start, end time.Time) *ContentIterator {
+	it := &ContentIterator{client: c, ctx: ctx}
+	if end.Sub(start) > MaxContentSpan {
+		it.err = fmt.Errorf("start (%v) and end (%v) must be <=24 hrs apart", start, end)
+		return it
+	}
+	if end.Before(start) {
+		it.err = fmt.Errorf("start (%v) must be before end (%v)", start, end)
+		return it
+	}
+	it.nextURL = c.apiRootURL + "subscriptions/content"
+	it.query = url.Values{
+		"contentType": {contentType},
+		"startTime":   {start.UTC().Format(dateFmt)},
+		"endTime":     {end.UTC().Format(dateFmt)},
+	}
+	return it
+}
+
+// Next advances to the next available content, returning false when there is
+// no more content or an error occurred (see Err)
+func (it *ContentIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || it.nextURL == "" {
+			return false
+		}
+		it.fetch()
+	}
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Content returns the current available content
+func (it *ContentIterator) Content() Content {
+	return it.current
+}
+
+// Err returns the first error encountered while iterating, if any
+func (it *ContentIterator) Err() error {
+	return it.err
+}
+
+// fetch requests the next page of available content
+func (it *ContentIterator) fetch() {
+	it.client.log.Debugf("getting page of available content from %v", it.nextURL)
+	res, err := it.client.do(it.ctx, "GET", it.nextURL, it.query, nil)
+	if err != nil {
+		it.err = err
+		return
+	}
+	defer res.Body.Close()
+
+	var page []Content
+	if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+		it.err = fmt.Errorf("error decoding available content: %v", err)
+		return
+	}
+	it.page = page
+	it.nextURL = res.Header.Get("NextPageUri")
+	it.query = nil
+}
+
+// CheckContentURI returns an error unless uri is under the client's api root
+// (same scheme and host, and the tenant's feed path).  content uris can come
+// from outside the api (e.g., webhook notifications), and requests carry the
+// tenant's token, so they mustn't be sent anywhere else.
+func (c *Client) CheckContentURI(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("invalid content uri %q: %v", uri, err)
+	}
+	root, err := url.Parse(c.apiRootURL)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(u.Scheme, root.Scheme) || !strings.EqualFold(u.Host, root.Host) ||
+		!strings.HasPrefix(strings.ToLower(u.Path), strings.ToLower(root.Path)) {
+		return fmt.Errorf("content uri %q isn't under the api root %v", uri, c.apiRootURL)
+	}
+	return nil
+}
+
+// GetContent gets the events in a content blob
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#retrieving-content
+func (c *Client) GetContent(ctx context.Context, contentURI string) ([]map[string]interface{}, error) {
+	c.log.Debugf("getting content from %v", contentURI)
+	if err := c.CheckContentURI(contentURI); err != nil {
+		return nil, err
+	}
+	res, err := c.do(ctx, "GET", contentURI, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading content from %v: %v", contentURI, err)
+	}
+	var events []map[string]interface{}
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, &ContentDecodeError{ContentURI: contentURI, Payload: body, Err: err}
+	}
+	return events, nil
+}
@@ -0,0 +1,39 @@
+// +build !integration
+
+package o365api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetContentOnlyRequestsTheAPI(t *testing.T) {
+	requested := false
+	attacker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer attacker.Close()
+
+	c, err := NewClient(Config{AuthMode: AuthModeSecret, ClientSecret: "secret", DirectoryID: "d"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, uri := range []string{
+		attacker.URL + "/api/v1.0/d/activity/feed/audit/1",
+		"https://manage.office.com.attacker.example.com/api/v1.0/d/activity/feed/audit/1",
+		"https://manage.office.com/api/v1.0/other/activity/feed/audit/1",
+		"://bad",
+	} {
+		if _, err := c.GetContent(context.Background(), uri); err == nil {
+			t.Errorf("%v: expected an error", uri)
+		}
+	}
+	if requested {
+		t.Fatal("content was requested from another host")
+	}
+	if err := c.CheckContentURI("https://manage.office.com/api/v1.0/d/activity/feed/audit/1"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,96 @@
+package o365api
+
+import (
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// credentialForm returns the token request fields proving the client's
+// identity (e.g., client_secret)
+type credentialForm func() (url.Values, error)
+
+// loadedCredential is one version of a credential
+type loadedCredential struct {
+	form credentialForm
+}
+
+// fileStamp identifies a version of a file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// credential is the client's credential, reloaded when its files change so
+// secrets and certificates can be rotated without a restart.  until azure ad
+// accepts a reloaded credential, the last accepted one is kept to fall back on.
+type credential struct {
+	files []string // watched files, none for a client secret in the config
+	load  func() (credentialForm, error)
+	log   Logger
+
+	mutex    sync.Mutex // guards the fields below
+	stamps   []fileStamp
+	current  *loadedCredential
+	previous *loadedCredential // last accepted credential, if current hasn't been
+	accepts  bool              // current has been accepted
+}
+
+// get returns the current credential, reloading it if its files changed, and
+// the previous one to fall back on (nil if there's none)
+func (c *credential) get() (current, previous *loadedCredential, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	stamps, changed := c.changed()
+	if c.current != nil && !changed {
+		return c.current, c.previous, nil
+	}
+	form, err := c.load()
+	if err != nil {
+		if c.current == nil {
+			return nil, nil, err
+		}
+		// e.g., a file caught mid-write: keep the loaded credential and try again next time
+		c.log.Warnf("error reloading changed credential, still using the loaded one: %v", err)
+		return c.current, c.previous, nil
+	}
+	if c.current != nil {
+		c.log.Infof("credential files changed, reloaded credential")
+		if c.accepts {
+			c.previous = c.current
+		}
+	}
+	c.current, c.accepts, c.stamps = &loadedCredential{form: form}, false, stamps
+	return c.current, c.previous, nil
+}
+
+// accepted records that azure ad accepted a credential, so if it's the current
+// one there's no need to keep the previous one
+func (c *credential) accepted(l *loadedCredential) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if l == c.current {
+		c.accepts, c.previous = true, nil
+	}
+}
+
+// changed stats the credential's files, returning their stamps and whether
+// they differ from the loaded credential's (files that can't be stat'ed count
+// as changed, so the reload reports the error)
+func (c *credential) changed() ([]fileStamp, bool) {
+	stamps := make([]fileStamp, len(c.files))
+	changed := len(c.stamps) != len(c.files)
+	for i, f := range c.files {
+		info, err := os.Stat(f)
+		if err != nil {
+			changed = true
+			continue
+		}
+		stamps[i] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		if !changed && stamps[i] != c.stamps[i] {
+			changed = true
+		}
+	}
+	return stamps, changed
+}
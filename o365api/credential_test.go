@@ -0,0 +1,160 @@
+// +build !integration
+
+package o365api
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// secretServer issues tokens (named for the secret) for the secrets in valid,
+// and records the secrets tried
+type secretServer struct {
+	*httptest.Server
+	mutex sync.Mutex
+	valid map[string]bool
+	tried []string
+}
+
+func newSecretServer(valid ...string) *secretServer {
+	s := &secretServer{valid: map[string]bool{}}
+	for _, secret := range valid {
+		s.valid[secret] = true
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret := r.FormValue("client_secret")
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.tried = append(s.tried, secret)
+		if !s.valid[secret] {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_client"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"token_type":"Bearer","access_token":"%s","expires_on":"%d"}`, secret, time.Now().Add(time.Hour).Unix())
+	}))
+	return s
+}
+
+// accept sets whether the server accepts secret
+func (s *secretServer) accept(secret string, ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.valid[secret] = ok
+}
+
+// triedSince returns the secrets tried since the first n
+func (s *secretServer) triedSince(n int) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return strings.Join(s.tried[n:], ",")
+}
+
+func TestRotatedSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", "o365beat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "secret")
+	// each version is written with a later modification time, so the reload
+	// doesn't depend on the file system's timestamp resolution
+	modTime := time.Now().Add(-time.Hour)
+	write := func(secret string) {
+		if err := ioutil.WriteFile(path, []byte(secret+"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		modTime = modTime.Add(time.Minute)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := newSecretServer("one")
+	defer s.Close()
+	write("one")
+	c, err := NewClient(Config{AuthMode: AuthModeSecret, ClientSecretPath: path, LoginURL: s.URL, ResourceURL: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		change    func()
+		wantToken string
+		wantTried string
+	}{
+		{"initial secret", func() {}, "one", "one"},
+		{"unchanged file", func() {}, "one", "one"},
+		{"rotated before azure ad accepts it", func() { write("two") }, "one", "two,one"},
+		{"rotated secret accepted", func() { s.accept("two", true) }, "two", "two"},
+		{"rejected secret falls back", func() { write("three") }, "two", "three,two"},
+		{"empty file keeps the loaded secret", func() { write("") }, "two", "three,two"},
+		{"missing file keeps the loaded secret", func() { os.Remove(path) }, "two", "three,two"},
+		{"restored file", func() { s.accept("three", true); write("three") }, "three", "three"},
+		{"both secrets rejected", func() { s.accept("three", false); write("four") }, "", "four,three"},
+	}
+	for _, test := range tests {
+		test.change()
+		n := len(s.tried)
+		token, err := c.authenticate(context.Background())
+		if got := s.triedSince(n); got != test.wantTried {
+			t.Errorf("%v: tried %v, want %v", test.name, got, test.wantTried)
+		}
+		if test.wantToken == "" {
+			if !isAuthRejected(err) {
+				t.Errorf("%v: got %v, want the rejection", test.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: %v", test.name, err)
+			continue
+		}
+		if token.AccessToken != test.wantToken {
+			t.Errorf("%v: got token %v, want %v", test.name, token.AccessToken, test.wantToken)
+		}
+	}
+}
+
+func TestUnreadableSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", "o365beat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "secret")
+	s := newSecretServer()
+	defer s.Close()
+	// with no loaded secret to keep, the error is returned without a request
+	for _, data := range []string{"", " \n"} {
+		if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		c, err := NewClient(Config{AuthMode: AuthModeSecret, ClientSecretPath: path, LoginURL: s.URL, ResourceURL: s.URL})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.authenticate(context.Background()); err == nil || !strings.Contains(err.Error(), "is empty") {
+			t.Errorf("secret %q: got %v, want an error for the empty file", data, err)
+		}
+	}
+	c, err := NewClient(Config{AuthMode: AuthModeSecret, ClientSecretPath: filepath.Join(dir, "missing"), LoginURL: s.URL, ResourceURL: s.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.authenticate(context.Background()); err == nil || !strings.Contains(err.Error(), "failed to read") {
+		t.Errorf("got %v, want an error for the missing file", err)
+	}
+	if len(s.tried) > 0 {
+		t.Errorf("requested tokens with %v", s.tried)
+	}
+}
@@ -0,0 +1,113 @@
+package o365api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Error codes returned by the API in the json error body (APIError.Code)
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#errors
+const (
+	ErrorCodeMissingPermission   = "AF10001" // the token doesn't include ActivityFeed.Read
+	ErrorCodeTenantNotFound      = "AF20011" // the tenant doesn't exist or has been deleted
+	ErrorCodeInvalidContentType  = "AF20020"
+	ErrorCodeWebhookInvalid      = "AF20021" // the webhook endpoint couldn't be validated
+	ErrorCodeNoSubscription      = "AF20022" // no subscription for the content type
+	ErrorCodeSubscriptionOff     = "AF20023" // the subscription was disabled
+	ErrorCodeInvalidTimeSpan     = "AF20030" // start and end are >24 hours apart or >7 days ago
+	ErrorCodeContentNotFound     = "AF20050" // the content blob doesn't exist
+	ErrorCodeContentExpired      = "AF20051" // the content blob is >7 days old and can't be retrieved
+	ErrorCodeInvalidContentID    = "AF20052"
+	ErrorCodeTooManyRequests     = "AF429"
+	ErrorCodeInternalServerError = "AF50000" // retry the request
+)
+
+// APIError is a non-2xx response from the API, with the error code and message
+// from its json body (if it has one)
+type APIError struct {
+	StatusCode int
+	Code       string        // e.g., ErrorCodeNoSubscription, empty if the body had none
+	Message    string        // the error body's message, or the raw body if it isn't json
+	Method     string        // of the failed request
+	URL        string        // of the failed request, without its query
+	Attempts   int           // requests made, including retries
+	RetryAfter time.Duration // from the Retry-After header, zero if none
+}
+
+func (e *APIError) Error() string {
+	code := e.Code
+	if code == "" {
+		code = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("api request %v %v failed after %v attempt(s): %v %v: %v", e.Method, e.URL, e.Attempts, e.StatusCode, code, e.Message)
+}
+
+// apiErrorBody is the json body the API returns along with non-200 statuses
+type apiErrorBody struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// newAPIError decodes a non-2xx response (body is the already-read response body)
+func newAPIError(req *http.Request, res *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: res.StatusCode,
+		Method:     req.Method,
+		URL:        req.URL.Scheme + "://" + req.URL.Host + req.URL.Path,
+		Message:    strings.TrimSpace(string(body)),
+	}
+	var b apiErrorBody
+	if err := json.Unmarshal(body, &b); err == nil && b.Error.Code != "" {
+		e.Code, e.Message = b.Error.Code, b.Error.Message
+	}
+	if wait, ok := retryAfter(res, time.Now()); ok {
+		e.RetryAfter = wait
+	}
+	return e
+}
+
+// ContentDecodeError is a content blob that isn't a json array of events
+type ContentDecodeError struct {
+	ContentURI string
+	Payload    []byte // the blob as received
+	Err        error
+}
+
+func (e *ContentDecodeError) Error() string {
+	return fmt.Sprintf("error decoding content from %v: %v", e.ContentURI, e.Err)
+}
+
+// ErrorCode returns the API error code of err, or "" if err isn't an APIError
+// (or has no code)
+func ErrorCode(err error) string {
+	if e, ok := err.(*APIError); ok {
+		return e.Code
+	}
+	return ""
+}
+
+// IsSubscriptionMissing reports whether err means the content type's
+// subscription doesn't exist or was disabled, so it needs to be (re)started
+func IsSubscriptionMissing(err error) bool {
+	code := ErrorCode(err)
+	return code == ErrorCodeNoSubscription || code == ErrorCodeSubscriptionOff
+}
+
+// IsThrottled reports whether err means requests are being throttled (after
+// the client's own retries), so the caller should back off
+func IsThrottled(err error) bool {
+	e, ok := err.(*APIError)
+	return ok && (e.Code == ErrorCodeTooManyRequests || e.StatusCode == http.StatusTooManyRequests)
+}
+
+// IsContentGone reports whether err means a content blob has expired or no
+// longer exists, so retrying it is pointless
+func IsContentGone(err error) bool {
+	code := ErrorCode(err)
+	return code == ErrorCodeContentExpired || code == ErrorCodeContentNotFound
+}
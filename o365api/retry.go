@@ -0,0 +1,125 @@
+package o365api
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how throttled (or otherwise temporarily failing) API
+// requests are retried before the error is returned
+type RetryPolicy struct {
+	MaxAttempts    int           // including the first request
+	InitialBackoff time.Duration // doubled after each attempt
+	MaxBackoff     time.Duration // cap on a single backoff (not Retry-After)
+	MaxElapsed     time.Duration // total time budget for one request and its retries
+}
+
+// DefaultRetryPolicy is a reasonable RetryPolicy for most tenants
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    8,
+	InitialBackoff: 1 * time.Second,
+	MaxBackoff:     2 * time.Minute,
+	MaxElapsed:     15 * time.Minute,
+}
+
+// retryableErrorCodes are the API error codes (in the json error body) that
+// indicate the request was throttled or failed temporarily and should be retried later
+var retryableErrorCodes = map[string]bool{
+	ErrorCodeTooManyRequests:     true,
+	ErrorCodeInternalServerError: true,
+}
+
+// retryableStatusCodes are http statuses that indicate a temporary condition
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusServiceUnavailable: true,
+}
+
+// jitter is a goroutine-safe random source for backoff jitter
+var jitter = struct {
+	sync.Mutex
+	*rand.Rand
+}{Rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+// shouldRetry reports whether a non-200 api response is throttling or another
+// temporary condition worth retrying
+func shouldRetry(e *APIError) bool {
+	return retryableStatusCodes[e.StatusCode] || retryableErrorCodes[e.Code]
+}
+
+// retryAfter parses the Retry-After header, which is either a number of
+// seconds or an http date (https://tools.ietf.org/html/rfc7231#section-7.1.3)
+func retryAfter(res *http.Response, now time.Time) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if wait := t.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// retrier tracks attempts and elapsed time for a single api request
+type retrier struct {
+	policy  RetryPolicy
+	attempt int // attempts made so far
+	started time.Time
+}
+
+func newRetrier(p RetryPolicy) *retrier {
+	return &retrier{policy: p, started: time.Now()}
+}
+
+// next records a failed attempt and returns how long to wait before the next
+// one, or false if the attempt or time budget is exhausted.  res may be nil
+// (e.g., for network errors).  Retry-After is honored when present, otherwise
+// the wait is an exponential backoff with jitter.
+func (r *retrier) next(res *http.Response) (time.Duration, bool) {
+	r.attempt++
+	if r.attempt >= r.policy.MaxAttempts {
+		return 0, false
+	}
+
+	now := time.Now()
+	wait, ok := retryAfter(res, now)
+	if !ok {
+		wait = r.backoff()
+	}
+	if r.policy.MaxElapsed > 0 && now.Add(wait).Sub(r.started) > r.policy.MaxElapsed {
+		return 0, false
+	}
+	return wait, true
+}
+
+// backoff returns the exponential backoff for the current attempt, capped at
+// MaxBackoff, with "equal jitter" (half fixed, half random) so concurrent
+// downloads don't all retry at the same moment
+func (r *retrier) backoff() time.Duration {
+	d := r.policy.InitialBackoff
+	for i := 1; i < r.attempt && (r.policy.MaxBackoff <= 0 || d < r.policy.MaxBackoff); i++ {
+		d *= 2
+	}
+	if r.policy.MaxBackoff > 0 && d > r.policy.MaxBackoff {
+		d = r.policy.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	jitter.Lock()
+	defer jitter.Unlock()
+	return half + time.Duration(jitter.Int63n(int64(d-half)+1))
+}
@@ -0,0 +1,211 @@
+// +build !integration
+
+package o365api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client for an api at url, with a token so requests
+// don't authenticate
+func newTestClient(t *testing.T, url string, retry RetryPolicy) *Client {
+	c, err := NewClient(Config{
+		AuthMode:     AuthModeSecret,
+		ClientSecret: "secret",
+		DirectoryID:  "d",
+		ResourceURL:  url,
+		Retry:        retry,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.tokens.token = testToken(time.Hour)
+	return c
+}
+
+// failingServer responds to the first failures requests with fail, then with 200
+func failingServer(failures int32, fail func(w http.ResponseWriter)) (*httptest.Server, *int32) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			fail(w)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	return srv, &requests
+}
+
+var fastRetries = RetryPolicy{MaxAttempts: 4, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond, MaxElapsed: time.Minute}
+
+func TestRetries(t *testing.T) {
+	tests := map[string]func(w http.ResponseWriter){
+		"429": func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+		"503": func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+		"AF429": func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":"AF429","message":"Too many requests."}}`))
+		},
+		"AF50000": func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":{"code":"AF50000","message":"An internal error occurred."}}`))
+		},
+	}
+	for name, fail := range tests {
+		srv, requests := failingServer(2, fail)
+		c := newTestClient(t, srv.URL, fastRetries)
+		res, err := c.do(context.Background(), "GET", srv.URL+"/x", nil, nil)
+		if err != nil {
+			t.Errorf("%v: %v", name, err)
+		} else {
+			res.Body.Close()
+		}
+		if n := atomic.LoadInt32(requests); n != 3 {
+			t.Errorf("%v: got %v request(s), want 3", name, n)
+		}
+		srv.Close()
+	}
+}
+
+func TestNoRetries(t *testing.T) {
+	srv, requests := failingServer(1, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"AF20022","message":"No subscription found for the specified content type"}}`))
+	})
+	defer srv.Close()
+	c := newTestClient(t, srv.URL, fastRetries)
+	_, err := c.do(context.Background(), "GET", srv.URL+"/x", nil, nil)
+	if ErrorCode(err) != ErrorCodeNoSubscription {
+		t.Fatalf("got %v", err)
+	}
+	if n := atomic.LoadInt32(requests); n != 1 {
+		t.Fatalf("got %v request(s), want 1", n)
+	}
+}
+
+func TestTruncatedErrorBody(t *testing.T) {
+	srv, requests := failingServer(1, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Length", "1000")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"AF20022"`))
+	})
+	defer srv.Close()
+	c := newTestClient(t, srv.URL, fastRetries)
+	_, err := c.do(context.Background(), "GET", srv.URL+"/x", nil, nil)
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusBadRequest || !strings.Contains(err.Error(), "error reading response body") {
+		t.Fatalf("got %v, want the status and the read error", err)
+	}
+	if n := atomic.LoadInt32(requests); n != 1 {
+		t.Fatalf("got %v request(s), want 1", n)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		header string
+		wait   time.Duration
+		ok     bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"120", 2 * time.Minute, true},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+	}
+	for _, test := range tests {
+		res := &http.Response{Header: http.Header{}}
+		if test.header != "" {
+			res.Header.Set("Retry-After", test.header)
+		}
+		wait, ok := retryAfter(res, now)
+		if wait != test.wait || ok != test.ok {
+			t.Errorf("%q: got %v, %v, want %v, %v", test.header, wait, ok, test.wait, test.ok)
+		}
+	}
+}
+
+func TestRetryAfterIsHonored(t *testing.T) {
+	srv, _ := failingServer(1, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer srv.Close()
+	c := newTestClient(t, srv.URL, fastRetries)
+	start := time.Now()
+	res, err := c.do(context.Background(), "GET", srv.URL+"/x", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("retried after %v, before Retry-After", elapsed)
+	}
+}
+
+func TestRetriesStopAtMaxAttempts(t *testing.T) {
+	srv, requests := failingServer(100, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer srv.Close()
+	c := newTestClient(t, srv.URL, fastRetries)
+	_, err := c.do(context.Background(), "GET", srv.URL+"/x", nil, nil)
+	e, ok := err.(*APIError)
+	if !ok || e.StatusCode != http.StatusServiceUnavailable || e.Attempts != 4 {
+		t.Fatalf("got %v", err)
+	}
+	if n := atomic.LoadInt32(requests); n != 4 {
+		t.Fatalf("got %v request(s), want 4", n)
+	}
+}
+
+func TestRetriesStopAtMaxElapsed(t *testing.T) {
+	srv, requests := failingServer(100, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer srv.Close()
+	policy := fastRetries
+	policy.MaxElapsed = 30 * time.Second
+	c := newTestClient(t, srv.URL, policy)
+	start := time.Now()
+	_, err := c.do(context.Background(), "GET", srv.URL+"/x", nil, nil)
+	if !IsThrottled(err) {
+		t.Fatalf("got %v", err)
+	}
+	if n := atomic.LoadInt32(requests); n != 1 || time.Since(start) > 10*time.Second {
+		t.Fatalf("got %v request(s) in %v, want 1 without waiting", n, time.Since(start))
+	}
+}
+
+func TestRetryWaitIsCancelled(t *testing.T) {
+	srv, requests := failingServer(100, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer srv.Close()
+	c := newTestClient(t, srv.URL, DefaultRetryPolicy)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.do(ctx, "GET", srv.URL+"/x", nil, nil)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("got %v", err)
+	}
+	if n := atomic.LoadInt32(requests); n != 1 || time.Since(start) > 10*time.Second {
+		t.Fatalf("got %v request(s) in %v, want 1 before cancellation", n, time.Since(start))
+	}
+}
@@ -0,0 +1,81 @@
+package o365api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Subscription is a content type subscription and its associated webhook
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#list-current-subscriptions
+type Subscription struct {
+	ContentType string   `json:"contentType"`
+	Status      string   `json:"status"` // "enabled" or "disabled"
+	Webhook     *Webhook `json:"webhook"`
+}
+
+// Webhook describes where the API sends notifications about new content
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#start-a-subscription
+type Webhook struct {
+	Status     string `json:"status,omitempty"` // set by the API
+	Address    string `json:"address"`
+	AuthID     string `json:"authId,omitempty"`
+	Expiration string `json:"expiration,omitempty"`
+}
+
+// ListSubscriptions gets a collection of the current subscriptions and associated webhooks
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#list-current-subscriptions
+func (c *Client) ListSubscriptions(ctx context.Context) ([]Subscription, error) {
+	c.log.Debugf("getting content subscriptions from %v", c.apiRootURL+"subscriptions/list")
+	res, err := c.do(ctx, "GET", c.apiRootURL+"subscriptions/list", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var subs []Subscription
+	if err := json.NewDecoder(res.Body).Decode(&subs); err != nil {
+		return nil, fmt.Errorf("error decoding subscriptions: %v", err)
+	}
+	c.log.Debugf("got these subscriptions: %v", subs)
+	return subs, nil
+}
+
+// StartSubscription starts a subscription to the specified content type, optionally
+// with a webhook (which the API validates before the subscription is created)
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#start-a-subscription
+func (c *Client) StartSubscription(ctx context.Context, contentType string, webhook *Webhook) (*Subscription, error) {
+	c.log.Debugf("subscribing to %v at %s", contentType, c.apiRootURL+"subscriptions/start")
+	query := url.Values{"contentType": {contentType}}
+	var body interface{}
+	if webhook != nil {
+		body = map[string]*Webhook{"webhook": webhook}
+	}
+	res, err := c.do(ctx, "POST", c.apiRootURL+"subscriptions/start", query, body)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var sub Subscription
+	if err := json.NewDecoder(res.Body).Decode(&sub); err != nil {
+		return nil, fmt.Errorf("error decoding subscription: %v", err)
+	}
+	c.log.Debugf("got this subscription response: %v", sub)
+	return &sub, nil
+}
+
+// StopSubscription stops the subscription to the specified content type
+// (content for the type is no longer available once stopped)
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#stop-a-subscription
+func (c *Client) StopSubscription(ctx context.Context, contentType string) error {
+	c.log.Debugf("unsubscribing from %v at %s", contentType, c.apiRootURL+"subscriptions/stop")
+	query := url.Values{"contentType": {contentType}}
+	res, err := c.do(ctx, "POST", c.apiRootURL+"subscriptions/stop", query, nil)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
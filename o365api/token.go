@@ -0,0 +1,357 @@
+package o365api
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// tokenExpiryBuffer is how long before expiry a token is no longer used
+	tokenExpiryBuffer = time.Minute
+	// tokenRefreshAhead is how long before expiry RefreshTokens replaces a token
+	tokenRefreshAhead = 5 * time.Minute
+	// tokenRetryInterval is how long RefreshTokens waits after a failed refresh
+	tokenRetryInterval = 30 * time.Second
+)
+
+// TokenStats describe a client's token, for metrics
+type TokenStats struct {
+	Acquired  time.Time // when the current token was acquired (even if it came from the cache), zero if none
+	ExpiresOn time.Time // when the current token expires, zero if none
+	Refreshes int64     // tokens acquired from azure ad
+	CacheHits int64     // tokens loaded from the TokenCache
+	Failures  int64     // failed token acquisitions
+}
+
+// Age is how old the current token is, zero if there's none
+func (s TokenStats) Age(now time.Time) time.Duration {
+	if s.Acquired.IsZero() {
+		return 0
+	}
+	return now.Sub(s.Acquired)
+}
+
+// tokenProvider holds a client's token, acquiring a new one when it's missing
+// or about to expire.  it's safe for concurrent use: callers wait for a single
+// acquisition rather than each authenticating.
+type tokenProvider struct {
+	acquire  func(ctx context.Context) (*authInfo, error)
+	cache    *TokenCache // optional
+	cacheKey string
+	log      Logger
+
+	mutex      sync.Mutex // guards the fields below, held while acquiring
+	token      *authInfo
+	stats      TokenStats
+	cacheTried bool // the cache is only read for the first token
+}
+
+// get returns a usable token, acquiring one if needed
+func (p *tokenProvider) get(ctx context.Context) (*authInfo, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.getLocked(ctx)
+}
+
+func (p *tokenProvider) getLocked(ctx context.Context) (*authInfo, error) {
+	if p.token != nil && time.Now().Before(p.token.expiresOn().Add(-tokenExpiryBuffer)) {
+		return p.token, nil
+	}
+	if !p.cacheTried && p.cache != nil {
+		p.cacheTried = true
+		cached, err := p.cache.load(p.cacheKey)
+		if err != nil {
+			p.log.Warnf("error reading token cache %v, acquiring a new token: %v", p.cache.path, err)
+		} else if cached != nil && time.Now().Before(cached.Token.expiresOn().Add(-tokenExpiryBuffer)) {
+			p.log.Infof("using cached token (expires %v)", cached.Token.expiresOn())
+			p.token = cached.Token
+			p.stats.Acquired, p.stats.ExpiresOn = cached.Acquired, cached.Token.expiresOn()
+			p.stats.CacheHits++
+			return cached.Token, nil
+		}
+	}
+	p.log.Infof("token missing or expired, authenticating")
+	return p.refreshLocked(ctx)
+}
+
+// refresh replaces the token once it's due (see refreshAt), even if it's still
+// usable.  the first token comes through get, so it can be a cached one, and a
+// token another caller acquired in the meantime isn't replaced.
+func (p *tokenProvider) refresh(ctx context.Context) (*authInfo, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.token == nil {
+		return p.getLocked(ctx)
+	}
+	if time.Now().Before(p.token.expiresOn().Add(-tokenRefreshAhead)) {
+		return p.token, nil
+	}
+	return p.refreshLocked(ctx)
+}
+
+func (p *tokenProvider) refreshLocked(ctx context.Context) (*authInfo, error) {
+	token, err := p.acquire(ctx)
+	if err != nil {
+		p.stats.Failures++
+		return nil, err
+	}
+	p.log.Infof("token successfully acquired (expires %v)", token.expiresOn())
+	p.token = token
+	p.stats.Acquired, p.stats.ExpiresOn = time.Now(), token.expiresOn()
+	p.stats.Refreshes++
+	if p.cache != nil {
+		if err := p.cache.store(p.cacheKey, cachedToken{Token: token, Acquired: p.stats.Acquired}); err != nil {
+			p.log.Warnf("error writing token cache %v: %v", p.cache.path, err)
+		}
+	}
+	return token, nil
+}
+
+// refreshAt is when the current token should be replaced (now if there's none)
+func (p *tokenProvider) refreshAt() time.Time {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.token == nil {
+		return time.Now()
+	}
+	return p.token.expiresOn().Add(-tokenRefreshAhead)
+}
+
+func (p *tokenProvider) tokenStats() TokenStats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.stats
+}
+
+// RefreshTokens keeps the client's token fresh, replacing it ahead of expiry
+// until ctx is done, so requests don't wait on authentication.  it's optional:
+// without it, tokens are acquired when requests find them missing or expired.
+func (c *Client) RefreshTokens(ctx context.Context) {
+	for {
+		wait := time.Until(c.tokens.refreshAt())
+		if wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return
+			}
+		}
+		if _, err := c.tokens.refresh(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.log.Warnf("error refreshing token, retrying in %v: %v", tokenRetryInterval, err)
+			select {
+			case <-time.After(tokenRetryInterval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// TokenStats describe the client's current token
+func (c *Client) TokenStats() TokenStats {
+	return c.tokens.tokenStats()
+}
+
+// TokenCache persists tokens in a file encrypted with AES-256-GCM, so restarts
+// can reuse unexpired tokens.  one cache may be shared by many clients (it's
+// safe for concurrent use), each keeping its token under its tenant,
+// application and endpoints.
+type TokenCache struct {
+	path       string
+	passphrase []byte
+	mutex      sync.Mutex  // serializes reads and writes of the file, and guards the fields below
+	salt       []byte      // the salt aead's key was derived with, nil until one is read or chosen
+	aead       cipher.AEAD // nil until a key is derived
+}
+
+// cachedToken is a token in a TokenCache
+type cachedToken struct {
+	Token    *authInfo `json:"token"`
+	Acquired time.Time `json:"acquired"`
+}
+
+// token cache files hold tokenCacheMagic, the salt their key is derived from
+// the passphrase with, a nonce, and the encrypted tokens
+const (
+	tokenCacheMagic    = "o365beat token cache v2\n"
+	tokenCacheSaltSize = 16
+)
+
+// scrypt parameters for deriving token cache keys (its recommended ones for
+// interactive logins: keys are only derived once per salt)
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// NewTokenCache returns a cache kept in path, encrypted with a key derived from
+// passphrase (which is required)
+func NewTokenCache(path, passphrase string) (*TokenCache, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("token cache %v needs a key to encrypt it", path)
+	}
+	return &TokenCache{path: path, passphrase: []byte(passphrase)}, nil
+}
+
+// cipherFor returns the cipher for a file with salt, deriving its key if it's
+// not the salt of the last one (the caller must hold tc.mutex)
+func (tc *TokenCache) cipherFor(salt []byte) (cipher.AEAD, error) {
+	if tc.aead != nil && bytes.Equal(salt, tc.salt) {
+		return tc.aead, nil
+	}
+	key, err := scrypt.Key(tc.passphrase, salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	tc.salt, tc.aead = salt, aead
+	return aead, nil
+}
+
+// tokenCacheKey identifies a client's token in a TokenCache
+func (c *Client) tokenCacheKey() string {
+	return strings.Join([]string{c.config.LoginURL, c.config.DirectoryID, c.config.ClientID, c.config.ResourceURL}, "|")
+}
+
+// load returns the cached token for key, nil if there's none
+func (tc *TokenCache) load(key string) (*cachedToken, error) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	tokens, err := tc.read()
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := tokens[key]; ok && t.Token != nil {
+		return &t, nil
+	}
+	return nil, nil
+}
+
+// store caches token under key, dropping expired tokens
+func (tc *TokenCache) store(key string, token cachedToken) error {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	tokens, err := tc.read()
+	if err != nil {
+		tokens = map[string]cachedToken{} // unreadable caches are replaced
+	}
+	now := time.Now()
+	for k, t := range tokens {
+		if t.Token == nil || now.After(t.Token.expiresOn()) {
+			delete(tokens, k)
+		}
+	}
+	tokens[key] = token
+	return tc.write(tokens)
+}
+
+// read decrypts the cache file, which may not exist yet
+func (tc *TokenCache) read() (map[string]cachedToken, error) {
+	tokens := map[string]cachedToken{}
+	data, err := ioutil.ReadFile(tc.path)
+	if os.IsNotExist(err) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, []byte(tokenCacheMagic)) {
+		return nil, fmt.Errorf("token cache isn't in the current format (e.g., it was written by an older version)")
+	}
+	data = data[len(tokenCacheMagic):]
+	if len(data) < tokenCacheSaltSize {
+		return nil, fmt.Errorf("token cache is truncated")
+	}
+	aead, err := tc.cipherFor(data[:tokenCacheSaltSize])
+	if err != nil {
+		return nil, err
+	}
+	data = data[tokenCacheSaltSize:]
+	nonceSize := aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, fmt.Errorf("token cache is truncated")
+	}
+	plain, err := aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting token cache (was its key changed?): %v", err)
+	}
+	if err := json.Unmarshal(plain, &tokens); err != nil {
+		return nil, fmt.Errorf("error decoding token cache: %v", err)
+	}
+	return tokens, nil
+}
+
+// write encrypts tokens to a temporary file and renames it over the cache file,
+// which only its owner can read.  the file keeps the salt it was read with, or
+// gets a random one.
+func (tc *TokenCache) write(tokens map[string]cachedToken) error {
+	plain, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	salt := tc.salt
+	if salt == nil {
+		salt = make([]byte, tokenCacheSaltSize)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return err
+		}
+	}
+	aead, err := tc.cipherFor(salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	data := append([]byte(tokenCacheMagic), salt...)
+	data = append(data, nonce...)
+	data = aead.Seal(data, nonce, plain, nil)
+
+	tmp, err := ioutil.TempFile(filepath.Dir(tc.path), filepath.Base(tc.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // fails harmlessly once renamed
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), tc.path)
+}
@@ -0,0 +1,165 @@
+// +build !integration
+
+package o365api
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testToken(expiresIn time.Duration) *authInfo {
+	return &authInfo{AccessToken: "t", ExpiresOn: strconv.FormatInt(time.Now().Add(expiresIn).Unix(), 10)}
+}
+
+func TestRefreshTokensUsesTheCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "o365beat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cache, err := NewTokenCache(filepath.Join(dir, "tokens"), "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	acquiredAt := time.Now().Add(-10 * time.Minute)
+	if err := cache.store("k", cachedToken{Token: testToken(time.Hour), Acquired: acquiredAt}); err != nil {
+		t.Fatal(err)
+	}
+
+	var acquired int32
+	p := &tokenProvider{
+		acquire: func(ctx context.Context) (*authInfo, error) {
+			atomic.AddInt32(&acquired, 1)
+			return testToken(time.Hour), nil
+		},
+		cache:    cache,
+		cacheKey: "k",
+		log:      nopLogger{},
+	}
+	c := &Client{tokens: p, log: nopLogger{}}
+
+	// the refresher and the first request race for the first token
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.RefreshTokens(ctx)
+	}()
+	if _, err := p.get(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for p.tokenStats().CacheHits == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&acquired); n != 0 {
+		t.Fatalf("acquired %v token(s), want the cached one", n)
+	}
+	if stats := p.tokenStats(); stats.CacheHits != 1 || stats.Refreshes != 0 || !stats.Acquired.Equal(acquiredAt) {
+		t.Fatalf("got %+v, want the cached token, acquired %v", stats, acquiredAt)
+	}
+}
+
+func TestTokenCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "o365beat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "tokens")
+	cache, err := NewTokenCache(path, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := cachedToken{Token: testToken(time.Hour), Acquired: time.Now().Add(-time.Minute).Truncate(time.Second)}
+	if err := cache.store("k", token); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, _ := NewTokenCache(path, "key")
+	got, err := reopened.load("k")
+	if err != nil || got == nil || *got.Token != *token.Token || !got.Acquired.Equal(token.Acquired) {
+		t.Fatalf("got %+v, %v, want %+v", got, err, token)
+	}
+	if got, err := reopened.load("other"); got != nil || err != nil {
+		t.Errorf("got %+v, %v for a key without a token", got, err)
+	}
+	wrongKey, _ := NewTokenCache(path, "wrong")
+	if _, err := wrongKey.load("k"); err == nil {
+		t.Error("read the cache with the wrong key")
+	}
+
+	// each cache file gets its own random salt
+	other, _ := NewTokenCache(filepath.Join(dir, "other"), "key")
+	if err := other.store("k", token); err != nil {
+		t.Fatal(err)
+	}
+	salt := func(path string) string {
+		data, err := ioutil.ReadFile(path)
+		if err != nil || len(data) < len(tokenCacheMagic)+tokenCacheSaltSize {
+			t.Fatalf("reading %v: %v", path, err)
+		}
+		return string(data[len(tokenCacheMagic) : len(tokenCacheMagic)+tokenCacheSaltSize])
+	}
+	if salt(path) == salt(filepath.Join(dir, "other")) {
+		t.Error("two caches have the same salt")
+	}
+
+	// caches in an older format can't be read, and are replaced
+	if err := ioutil.WriteFile(path, []byte("0123456789abcdef0123456789abcdef"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cache, _ = NewTokenCache(path, "key")
+	if _, err := cache.load("k"); err == nil {
+		t.Error("read a cache in an older format")
+	}
+	if err := cache.store("k", token); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := cache.load("k"); err != nil || got == nil {
+		t.Errorf("got %+v, %v after replacing an old cache", got, err)
+	}
+}
+
+func TestRefreshReplacesDueTokens(t *testing.T) {
+	var acquired int32
+	p := &tokenProvider{
+		acquire: func(ctx context.Context) (*authInfo, error) {
+			atomic.AddInt32(&acquired, 1)
+			return testToken(time.Hour), nil
+		},
+		log: nopLogger{},
+	}
+	ctx := context.Background()
+
+	// no token yet: acquired once, and not again while it's fresh
+	if _, err := p.refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&acquired); n != 1 {
+		t.Fatalf("acquired %v token(s), want 1", n)
+	}
+
+	// due (but still usable): replaced
+	p.token = testToken(tokenRefreshAhead - time.Minute)
+	if _, err := p.refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&acquired); n != 2 {
+		t.Fatalf("acquired %v token(s), want 2", n)
+	}
+}
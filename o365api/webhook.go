@@ -0,0 +1,21 @@
+package o365api
+
+// Webhook notifications are posted by the API to a subscription's webhook address
+// https://docs.microsoft.com/en-us/office/office-365-management-api/office-365-management-activity-api-reference#receiving-notifications
+
+// WebhookAuthIDHeader is the header carrying the webhook's AuthID in validation
+// requests and notifications
+const WebhookAuthIDHeader = "Webhook-AuthID"
+
+// WebhookValidation is the body of the request the API sends to a webhook when
+// a subscription is started; the webhook must respond with 200 OK
+type WebhookValidation struct {
+	ValidationCode string `json:"validationCode"`
+}
+
+// Notification announces newly available content to a webhook
+type Notification struct {
+	Content
+	TenantID string `json:"tenantId"`
+	ClientID string `json:"clientId"`
+}